@@ -19,7 +19,7 @@ func main() {
 		Verbose  bool    `name:"verbose" description:"Enable verbose output"`
 		Score    float64 `name:"score" description:"Your score" validate:"range=0-100,error_range=Score must be between 0 and 100"`
 		Status   string  `name:"status" description:"Your status (active/inactive)" validate:"in=active|inactive,error_in=Status must be active or inactive"`
-		Password string  `name:"password" description:"Your password" validate:"required,pattern=^(?=.*[a-z])(?=.*[A-Z])(?=.*\\d)[a-zA-Z\\d]{8,}$,error_pattern=Password must contain at least one lowercase letter, one uppercase letter, and one number, and be at least 8 characters long"`
+		Password string  `name:"password" description:"Your password" validate:"required,pattern=^[a-zA-Z\\d]{8}[a-zA-Z\\d]*$,error_pattern=Password must be at least 8 letters or numbers"`
 	}
 
 	var config Config
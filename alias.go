@@ -0,0 +1,112 @@
+package cliz
+
+import (
+	"sort"
+	"strings"
+)
+
+// Aliases adds alternative names for the command, so that "rm" or "del" can
+// be used in place of "remove". Aliases are listed next to the command name
+// in the parent's help output.
+// It panics if an alias is already used by another subcommand of the parent.
+func (c *Command) Aliases(aliases ...string) *Command {
+	c.aliases = append(c.aliases, aliases...)
+	if c.parent != nil {
+		c.parent.registerAliases(c, aliases)
+	}
+	return c
+}
+
+// registerAliases makes the aliases of a subcommand resolvable.
+func (c *Command) registerAliases(cmd *Command, aliases []string) {
+	for _, alias := range aliases {
+		if existing, ok := c.subCommandsMap[alias]; ok && existing != cmd {
+			panic("Aliases: '" + alias + "' is already used by command '" + existing.name + "'")
+		}
+		c.subCommandsMap[alias] = cmd
+	}
+	if width := len(cmd.displayName()); width > c.longestSubcommand {
+		c.longestSubcommand = width
+	}
+}
+
+// displayName returns the command name followed by its aliases, if any.
+func (c *Command) displayName() string {
+	if len(c.aliases) == 0 {
+		return c.name
+	}
+	return c.name + " (" + strings.Join(c.aliases, ", ") + ")"
+}
+
+// AllowPrefixMatching enables resolving subcommands by a unique prefix of
+// their name or one of their aliases, so that "myapp serv" runs "server".
+// Prefixes are only matched for the argument directly following a command.
+// An ambiguous prefix is reported as an *AmbiguousCommandError listing the candidates.
+func (c *Cli) AllowPrefixMatching(allow bool) *Cli {
+	c.prefixMatching = allow
+	return c
+}
+
+// lookupSubCommand resolves a subcommand by name or alias, falling back to
+// a unique prefix when prefix is true and prefix matching is enabled.
+// It returns nil if no subcommand matches.
+func (c *Command) lookupSubCommand(name string, prefix bool) (*Command, error) {
+	if cmd := c.subCommandsMap[name]; cmd != nil {
+		return cmd, nil
+	}
+	if !prefix || c.app == nil || !c.app.prefixMatching || name == "" || strings.HasPrefix(name, "-") {
+		return nil, nil
+	}
+
+	var matches []*Command
+	for key, cmd := range c.subCommandsMap {
+		if cmd.hidden || !strings.HasPrefix(key, name) {
+			continue
+		}
+		if !containsCommand(matches, cmd) {
+			matches = append(matches, cmd)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	}
+	candidates := make([]string, len(matches))
+	for i, cmd := range matches {
+		candidates[i] = cmd.name
+	}
+	sort.Strings(candidates)
+	return nil, &AmbiguousCommandError{Command: c.commandPath, Name: name, Candidates: candidates}
+}
+
+// StrictSubcommands makes an unknown subcommand an error on commands that have
+// subcommands but no action, instead of treating it as a positional argument.
+// The returned UnknownCommandError suggests similar command names.
+func (c *Cli) StrictSubcommands(strict bool) *Cli {
+	c.strictSubcommands = strict
+	return c
+}
+
+// unknownCommand builds an UnknownCommandError for a name that is not a
+// subcommand of c, suggesting visible subcommands and aliases.
+func (c *Command) unknownCommand(name string) *UnknownCommandError {
+	var names []string
+	for key, cmd := range c.subCommandsMap {
+		if !cmd.hidden {
+			names = append(names, key)
+		}
+	}
+	return &UnknownCommandError{Command: c.commandPath, Name: name, Suggestions: suggest(name, names)}
+}
+
+// containsCommand reports whether cmd is in commands.
+func containsCommand(commands []*Command, cmd *Command) bool {
+	for _, c := range commands {
+		if c == cmd {
+			return true
+		}
+	}
+	return false
+}
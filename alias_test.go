@@ -0,0 +1,85 @@
+package cliz
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAliases(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var executed int
+	cli.NewSubCommand("remove", "remove files").Aliases("rm", "del").Action(func() error {
+		executed++
+		return nil
+	})
+	for _, name := range []string{"remove", "rm", "del"} {
+		err := cli.Run(name)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if executed != 3 {
+		t.Fatalf("Expected command to run 3 times, got %d", executed)
+	}
+	if name := cli.RootCommand().subCommandsMap["rm"].displayName(); name != "remove (rm, del)" {
+		t.Fatalf("Expected display name 'remove (rm, del)', got '%s'", name)
+	}
+}
+
+func TestAliasesBeforeAddCommand(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	cmd := NewCommand("list", "list files").Aliases("ls")
+	cli.AddCommand(cmd)
+	if cli.RootCommand().subCommandsMap["ls"] != cmd {
+		t.Fatalf("Expected alias to be registered when the command is added")
+	}
+}
+
+func TestAliasConflict(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	cli.NewSubCommand("remove", "remove files").Aliases("rm")
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected panic for a duplicate alias")
+		}
+	}()
+	cli.NewSubCommand("rmdir", "remove directories").Aliases("rm")
+}
+
+func TestPrefixMatching(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var executed string
+	cli.NewSubCommand("server", "run the server").Action(func() error {
+		executed = "server"
+		return nil
+	})
+	cli.NewSubCommand("status", "show status").Action(func() error {
+		executed = "status"
+		return nil
+	})
+
+	err := cli.Run("serv")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if executed != "" {
+		t.Fatalf("Expected prefix matching to be disabled by default")
+	}
+
+	cli.AllowPrefixMatching(true)
+	err = cli.Run("serv")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if executed != "server" {
+		t.Fatalf("Expected server to run, got '%s'", executed)
+	}
+
+	err = cli.Run("s")
+	if err == nil {
+		t.Fatalf("Expected an error for an ambiguous prefix")
+	}
+	if !strings.Contains(err.Error(), "server, status") {
+		t.Fatalf("Expected candidates in error, got %v", err)
+	}
+}
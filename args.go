@@ -0,0 +1,133 @@
+package cliz
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ArgsRule checks the positional arguments of a command after its flags
+// have been parsed. Errors are reported as ArgsError.
+type ArgsRule func(cmd *Command, args []string) error
+
+// ArgsError is returned when the positional arguments of a command do not
+// satisfy its ArgsRule.
+type ArgsError struct {
+	Command string // Path of the command
+	UseLine string // Usage line of the command
+	Err     error  // Error returned by the rule
+}
+
+func (e *ArgsError) Error() string {
+	return fmt.Sprintf("%v, usage: %s", e.Err, e.UseLine)
+}
+
+func (e *ArgsError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns ExitCodeUsage.
+func (e *ArgsError) ExitCode() int {
+	return ExitCodeUsage
+}
+
+// Args sets the rule the positional arguments of the command must satisfy,
+// e.g. cmd.Args(cliz.ExactArgs(2)).
+func (c *Command) Args(rule ArgsRule) *Command {
+	c.argsRule = rule
+	return c
+}
+
+// Args sets the rule the positional arguments of the root command must satisfy.
+func (c *Cli) Args(rule ArgsRule) *Cli {
+	c.rootCommand.Args(rule)
+	return c
+}
+
+// checkArgs applies the command's ArgsRule to its positional arguments.
+func (c *Command) checkArgs() error {
+	if c.argsRule == nil {
+		return nil
+	}
+	if err := c.argsRule(c, c.positionalArgs); err != nil {
+		return &ArgsError{Command: c.commandPath, UseLine: c.UseLine(), Err: err}
+	}
+	return nil
+}
+
+// NoArgs accepts no positional arguments.
+func NoArgs() ArgsRule {
+	return func(cmd *Command, args []string) error {
+		if len(args) > 0 {
+			return fmt.Errorf("accepts no arguments, received %d", len(args))
+		}
+		return nil
+	}
+}
+
+// ExactArgs accepts exactly n positional arguments.
+func ExactArgs(n int) ArgsRule {
+	return func(cmd *Command, args []string) error {
+		if len(args) != n {
+			return fmt.Errorf("accepts %d arg(s), received %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+// MinimumArgs accepts at least n positional arguments.
+func MinimumArgs(n int) ArgsRule {
+	return func(cmd *Command, args []string) error {
+		if len(args) < n {
+			return fmt.Errorf("requires at least %d arg(s), received %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+// MaximumArgs accepts at most n positional arguments.
+func MaximumArgs(n int) ArgsRule {
+	return func(cmd *Command, args []string) error {
+		if len(args) > n {
+			return fmt.Errorf("accepts at most %d arg(s), received %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+// RangeArgs accepts between min and max positional arguments, inclusive.
+func RangeArgs(minArgs, maxArgs int) ArgsRule {
+	return func(cmd *Command, args []string) error {
+		if len(args) < minArgs || len(args) > maxArgs {
+			return fmt.Errorf("accepts between %d and %d arg(s), received %d", minArgs, maxArgs, len(args))
+		}
+		return nil
+	}
+}
+
+// OnlyValidArgs accepts only positional arguments from valid.
+// Invalid arguments are reported with suggestions of similar valid ones.
+func OnlyValidArgs(valid ...string) ArgsRule {
+	return func(cmd *Command, args []string) error {
+		for _, arg := range args {
+			if !slices.Contains(valid, arg) {
+				return fmt.Errorf("invalid argument %q, expected one of: %s%s",
+					arg, strings.Join(valid, ", "), didYouMean(suggest(arg, valid), ""))
+			}
+		}
+		return nil
+	}
+}
+
+// MatchAll accepts positional arguments that satisfy all of the rules,
+// e.g. MatchAll(ExactArgs(1), OnlyValidArgs("start", "stop")).
+func MatchAll(rules ...ArgsRule) ArgsRule {
+	return func(cmd *Command, args []string) error {
+		for _, rule := range rules {
+			if err := rule(cmd, args); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
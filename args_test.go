@@ -0,0 +1,104 @@
+package cliz
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestArgsRules(t *testing.T) {
+	tests := []struct {
+		rule  ArgsRule
+		valid []string
+		bad   []string
+	}{
+		{NoArgs(), nil, []string{"a"}},
+		{ExactArgs(2), []string{"a", "b"}, []string{"a"}},
+		{MinimumArgs(1), []string{"a", "b"}, nil},
+		{MaximumArgs(1), []string{"a"}, []string{"a", "b"}},
+		{RangeArgs(1, 2), []string{"a", "b"}, []string{"a", "b", "c"}},
+		{OnlyValidArgs("start", "stop"), []string{"stop"}, []string{"stat"}},
+		{MatchAll(ExactArgs(1), OnlyValidArgs("start")), []string{"start"}, []string{"start", "start"}},
+	}
+	for i, test := range tests {
+		cmd := NewCommand("test", "test command")
+		if err := test.rule(cmd, test.valid); err != nil {
+			t.Errorf("Rule %d: unexpected error for %v: %v", i, test.valid, err)
+		}
+		if err := test.rule(cmd, test.bad); err == nil && test.bad != nil {
+			t.Errorf("Rule %d: expected error for %v", i, test.bad)
+		}
+	}
+	if err := MinimumArgs(1)(NewCommand("test", ""), nil); err == nil {
+		t.Errorf("Expected MinimumArgs(1) to reject no arguments")
+	}
+}
+
+func TestCommandArgs(t *testing.T) {
+	cli := NewCli("myapp", "test description", "1.0.0")
+	called := false
+	cli.NewSubCommand("copy", "Copy files").Args(ExactArgs(2)).Action(func() error {
+		called = true
+		return nil
+	})
+	var handled error
+	cli.SetErrorFunction(func(path string, err error) error {
+		handled = err
+		return err
+	})
+
+	err := cli.Run("copy", "a.txt")
+	var argsErr *ArgsError
+	if !errors.As(err, &argsErr) {
+		t.Fatalf("Expected ArgsError, got %v", err)
+	}
+	if called {
+		t.Fatalf("Expected action not to run")
+	}
+	if argsErr.Command != "myapp copy" || handled != err {
+		t.Fatalf("Unexpected error %+v", argsErr)
+	}
+	expected := "accepts 2 arg(s), received 1, usage: myapp copy [flags]"
+	if err.Error() != expected {
+		t.Fatalf("Expected error '%s', got '%s'", expected, err.Error())
+	}
+	if code := ExitCodeOf(err); code != ExitCodeUsage {
+		t.Fatalf("Expected exit code %d, got %d", ExitCodeUsage, code)
+	}
+
+	if err := cli.Run("copy", "a.txt", "b.txt"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !called {
+		t.Fatalf("Expected action to run")
+	}
+}
+
+func TestCommandArgsCustomRule(t *testing.T) {
+	cli := NewCli("myapp", "test description", "1.0.0")
+	cli.Args(func(cmd *Command, args []string) error {
+		for _, arg := range args {
+			if !strings.HasSuffix(arg, ".txt") {
+				return errors.New("only .txt files are accepted")
+			}
+		}
+		return nil
+	})
+	cli.Action(func() error { return nil })
+
+	err := cli.Run("a.txt", "b.go")
+	if err == nil || !strings.HasPrefix(err.Error(), "only .txt files are accepted, usage: myapp") {
+		t.Fatalf("Expected custom rule error, got %v", err)
+	}
+	if err := cli.Run("a.txt"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestOnlyValidArgsSuggestion(t *testing.T) {
+	err := OnlyValidArgs("start", "stop")(NewCommand("test", ""), []string{"stat"})
+	expected := `invalid argument "stat", expected one of: start, stop, did you mean start or stop?`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error '%s', got '%v'", expected, err)
+	}
+}
@@ -1,19 +1,36 @@
 package cliz
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"text/template"
 )
 
 // Cli is the main CLI application object.
 // It manages the command hierarchy, flags, and execution flow.
 type Cli struct {
-	version        string                    // Application version string
-	rootCommand    *Command                  // Root command of the CLI hierarchy
-	defaultCommand *Command                  // Command to execute when no command is specified
-	preRunCommand  func(*Cli) error          // Callback executed before running any command
-	bannerFunction func(*Cli) string         // Callback to generate banner output
-	errorHandler   func(string, error) error // Custom error handler
+	version           string                    // Application version string
+	rootCommand       *Command                  // Root command of the CLI hierarchy
+	defaultCommand    *Command                  // Command to execute when no command is specified
+	preRunCommand     func(*Cli) error          // Callback executed before running any command
+	bannerFunction    func(*Cli) string         // Callback to generate banner output
+	errorHandler      func(string, error) error // Custom error handler
+	envPrefix         string                    // Prefix for derived environment variable names
+	configFiles       []string                  // Configuration files loaded before parsing flags
+	configFlag        string                    // Name of the flag that selects the configuration file
+	configs           []*configFile             // Configuration files loaded for the current run
+	handleSignals     bool                      // Whether to cancel the run context on SIGINT/SIGTERM
+	exitFunction      func(int)                 // Function used to exit the process
+	middleware        []Middleware              // Middleware wrapping every command
+	prefixMatching    bool                      // Whether subcommands can be resolved by a unique prefix
+	helpTemplate      *template.Template        // Template used to print help
+	out               io.Writer                 // Writer for regular output, os.Stdout if nil
+	errOut            io.Writer                 // Writer for errors and warnings, os.Stderr if nil
+	in                io.Reader                 // Reader for input, os.Stdin if nil
+	completion        bool                      // Whether shell completion is enabled
+	strictSubcommands bool                      // Whether unknown subcommands are an error on commands without an action
 }
 
 // defaultBannerFunction generates the default application banner.
@@ -34,6 +51,7 @@ func NewCli(name, description, version string) *Cli {
 	cli := &Cli{
 		version:        version,
 		bannerFunction: defaultBannerFunction,
+		exitFunction:   os.Exit,
 	}
 	cli.rootCommand = NewCommand(name, description)
 	cli.rootCommand.setApp(cli)
@@ -51,6 +69,12 @@ func (c *Cli) Name() string {
 	return c.rootCommand.name
 }
 
+// RootCommand returns the root command of the application.
+// It can be used with package level helpers such as Var.
+func (c *Cli) RootCommand() *Command {
+	return c.rootCommand
+}
+
 // ShortDescription returns the application short description (root command description).
 func (c *Cli) ShortDescription() string {
 	return c.rootCommand.shortdescription
@@ -76,11 +100,10 @@ func (c *Cli) AddCommand(command *Command) {
 	c.rootCommand.AddCommand(command)
 }
 
-// PrintBanner prints the application banner to standard output.
+// PrintBanner prints the application banner to the output writer.
 // The banner format is determined by the bannerFunction.
 func (c *Cli) PrintBanner() {
-	fmt.Println(c.bannerFunction(c))
-	fmt.Println("")
+	fmt.Fprintf(c.rootCommand.Output(), "%s\n\n", c.bannerFunction(c))
 }
 
 // PrintHelp prints the application's help information.
@@ -93,16 +116,32 @@ func (c *Cli) PrintHelp() {
 // If no arguments are provided, it reads from os.Args[1:].
 // This method initiates the command parsing and execution pipeline.
 func (c *Cli) Run(args ...string) error {
+	return c.RunContext(context.Background(), args...)
+}
+
+// RunContext executes the CLI application with the given context and arguments.
+// If no arguments are provided, it reads from os.Args[1:].
+// The context is passed to ActionContext callbacks; if HandleSignals is enabled
+// it is also cancelled on SIGINT or SIGTERM.
+func (c *Cli) RunContext(ctx context.Context, args ...string) error {
+	if c.handleSignals {
+		var stop func()
+		ctx, stop = notifySignals(ctx, c.exitFunction)
+		defer stop()
+	}
+	if args == nil {
+		args = os.Args[1:]
+	}
+	if c.completion && len(args) > 0 && args[0] == completeCommandName {
+		return c.runComplete(ctx, c.rootCommand.Output(), args[1:])
+	}
 	if c.preRunCommand != nil {
 		err := c.preRunCommand(c)
 		if err != nil {
 			return err
 		}
 	}
-	if args == nil {
-		args = os.Args[1:]
-	}
-	return c.rootCommand.run(args)
+	return c.rootCommand.execute(ctx, args)
 }
 
 // DefaultCommand sets the command to execute when no command is specified.
@@ -139,7 +178,7 @@ func (c *Cli) PreRun(callback func(*Cli) error) {
 // AddFlags adds multiple flags to the root command by reflecting on a struct.
 // The struct should be passed as a pointer.
 // This method uses struct tags to configure flags automatically.
-// Supported tags: `name:`, `description:`, `default:`, `pos:`, `sep:`.
+// Supported tags: `name:`, `description:`, `default:`, `short:`, `sep:`, `validate:`.
 func (c *Cli) AddFlags(flags any) *Cli {
 	c.rootCommand.AddFlags(flags)
 	return c
@@ -152,6 +191,13 @@ func (c *Cli) Action(callback Action) *Cli {
 	return c
 }
 
+// ActionContext sets a context-aware action callback for the root command.
+// The callback is executed when the root command is run without subcommands.
+func (c *Cli) ActionContext(callback ActionContext) *Cli {
+	c.rootCommand.ActionContext(callback)
+	return c
+}
+
 // LongDescription sets the long description for the root command.
 // The long description appears in detailed help output.
 func (c *Cli) LongDescription(longdescription string) *Cli {
@@ -163,7 +209,14 @@ func (c *Cli) LongDescription(longdescription string) *Cli {
 // This should only be called within the context of an action callback.
 // The returned slice contains all arguments that were not parsed as flags.
 func (c *Cli) OtherArgs() []string {
-	return c.rootCommand.flags.Args()
+	return c.rootCommand.OtherArgs()
+}
+
+// Alias adds a single character short form for a flag of the root command.
+// This is a convenience method that delegates to rootCommand.Alias.
+func (c *Cli) Alias(flagName, short string) *Cli {
+	c.rootCommand.Alias(flagName, short)
+	return c
 }
 
 // Bool adds a boolean flag to the root command.
@@ -387,7 +440,7 @@ func (c *Cli) Float64Slice(name, description string, variable *[]float64, valida
 // AddPositionalArgs adds positional arguments to the root command by reflecting on a struct.
 // The struct should be passed as a pointer.
 // This method uses struct tags to configure positional arguments automatically.
-// Supported tags: `position:`.
+// Supported tags: `position:`, `name:`, `description:`, `validate:`, `default:`, `sep:`.
 func (c *Cli) AddPositionalArgs(argsStruct any) *Cli {
 	c.rootCommand.AddPositionalArgs(argsStruct)
 	return c
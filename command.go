@@ -1,33 +1,56 @@
 package cliz
 
 import (
+	"context"
 	"flag"
 	"fmt"
-	"os"
 	"reflect"
 	"strings"
+	"text/template"
 )
 
 // Command represents a command that may be run by the user.
 // It contains all the information needed to define and execute a command,
 // including flags, subcommands, and action callbacks.
 type Command struct {
-	name              string                   // Name of the command
-	commandPath       string                   // Full path to the command (including parent commands)
-	shortdescription  string                   // Short description shown in help output
-	longdescription   string                   // Long description shown in detailed help
-	subCommands       []*Command               // List of subcommands
-	subCommandsMap    map[string]*Command      // Map for fast subcommand lookup
-	longestSubcommand int                      // Length of the longest subcommand name for formatting
-	actionCallback    Action                   // Action to execute when the command runs
-	app               *Cli                     // Reference to the parent Cli application
-	flags             *flag.FlagSet            // Flag set for command-specific flags
-	flagCount         int                      // Number of flags defined
-	helpFlag          bool                     // Whether the help flag was requested
-	hidden            bool                     // Whether the command is hidden from help
-	positionalArgsMap map[string]reflect.Value // Map for positional arguments by index
-	flagValidations   map[string][]Validator   // Map of flag names to validators
-	flagVariables     map[string]reflect.Value // Map of flag names to their variable addresses for validation
+	name                  string                    // Name of the command
+	commandPath           string                    // Full path to the command (including parent commands)
+	shortdescription      string                    // Short description shown in help output
+	longdescription       string                    // Long description shown in detailed help
+	subCommands           []*Command                // List of subcommands
+	subCommandsMap        map[string]*Command       // Map for fast subcommand lookup
+	longestSubcommand     int                       // Length of the longest subcommand name for formatting
+	actionCallback        ActionContext             // Action to execute when the command runs
+	ctx                   context.Context           // Context of the current run
+	parent                *Command                  // Command this command was added to
+	preRun                ActionContext             // Hook executed before the action
+	postRun               ActionContext             // Hook executed after the action
+	persistentPreRun      ActionContext             // Hook executed before the action of this command and its descendants
+	persistentPostRun     ActionContext             // Hook executed after the action of this command and its descendants
+	middleware            []Middleware              // Middleware wrapping this command and its descendants
+	aliases               []string                  // Alternative names of the command
+	examples              string                    // Usage examples shown in help and generated documentation
+	group                 string                    // Title of the group the command is listed under
+	helpTemplate          *template.Template        // Template overriding the application help template
+	deprecatedFlags       map[string]string         // Deprecation messages by flag name
+	flagCompletions       map[string]CompletionFunc // Functions completing flag values
+	positionalCompletions map[int]CompletionFunc    // Functions completing positional arguments
+	app                   *Cli                      // Reference to the parent Cli application
+	flags                 *flag.FlagSet             // Flag set for command-specific flags
+	flagCount             int                       // Number of flags defined
+	helpFlag              bool                      // Whether the help flag was requested
+	hidden                bool                      // Whether the command is hidden from help
+	positionals           []*positionalArg          // Struct fields bound to positional arguments, by index
+	argsRule              ArgsRule                  // Rule the positional arguments must satisfy
+	flagGroups            []flagGroup               // Constraints on combinations of flags
+	flagValidations       map[string][]Validator    // Map of flag names to validators
+	flagVariables         map[string]reflect.Value  // Map of flag names to their variable addresses for validation
+	flagSiblings          map[string]structFields   // Map of flag names to the fields of the struct they were added from
+	shortFlags            map[string]string         // Map of short aliases to flag names
+	flagShorts            map[string]string         // Map of flag names to their short aliases
+	flagEnvs              map[string][]string       // Map of flag names to bound environment variables
+	flagSources           map[string]valueSource    // Where each flag value was set from in the last parse
+	positionalArgs        []string                  // Non-flag arguments left after parsing
 }
 
 // Action defines the callback function that executes when the command runs.
@@ -35,19 +58,31 @@ type Command struct {
 // The function should return an error if the command execution fails.
 type Action func() error
 
+// ActionContext defines a callback function that executes when the command runs
+// and receives the run's context, the command and its positional arguments.
+// Long-running actions should return once the context is done.
+type ActionContext func(ctx context.Context, cmd *Command, args []string) error
+
 // NewCommand creates a new Command with the given name and description.
 // The command name should be unique within its parent command.
 // The description should be a concise summary of what the command does.
 func NewCommand(name string, description string) *Command {
 	command := &Command{
-		name:              name,
-		shortdescription:  description,
-		subCommandsMap:    make(map[string]*Command),
-		flags:             flag.NewFlagSet(name, flag.ExitOnError),
-		flagValidations:   make(map[string][]Validator),
-		flagVariables:     make(map[string]reflect.Value),
-		hidden:            false,
-		positionalArgsMap: make(map[string]reflect.Value),
+		name:                  name,
+		shortdescription:      description,
+		subCommandsMap:        make(map[string]*Command),
+		flags:                 flag.NewFlagSet(name, flag.ExitOnError),
+		flagValidations:       make(map[string][]Validator),
+		flagVariables:         make(map[string]reflect.Value),
+		flagSiblings:          make(map[string]structFields),
+		hidden:                false,
+		shortFlags:            make(map[string]string),
+		flagShorts:            make(map[string]string),
+		flagEnvs:              make(map[string][]string),
+		flagSources:           make(map[string]valueSource),
+		flagCompletions:       make(map[string]CompletionFunc),
+		positionalCompletions: make(map[int]CompletionFunc),
+		deprecatedFlags:       make(map[string]string),
 	}
 	return command
 }
@@ -64,13 +99,22 @@ func (c *Command) setParentCommandPath(parentCommandPath string) {
 	c.Bool("help", "Get help on the '"+strings.ToLower(c.commandPath)+"' command.", &c.helpFlag)
 }
 
-func (c *Command) inheritFlags(inheritFlags *flag.FlagSet) {
+func (c *Command) inheritFlags(parent *Command) {
 	// inherit flags
-	inheritFlags.VisitAll(func(f *flag.Flag) {
+	parent.flags.VisitAll(func(f *flag.Flag) {
 		if f.Name != "help" {
 			c.flags.Var(f.Value, f.Name, f.Usage)
 		}
 	})
+	// inherit short aliases
+	for short, name := range parent.shortFlags {
+		c.shortFlags[short] = name
+		c.flagShorts[name] = short
+	}
+	// inherit environment variable bindings
+	for name, vars := range parent.flagEnvs {
+		c.flagEnvs[name] = vars
+	}
 }
 
 func (c *Command) setApp(app *Cli) {
@@ -81,10 +125,32 @@ func (c *Command) setApp(app *Cli) {
 // The action will be executed when the command runs if no subcommand is specified.
 // Only one action can be set per command.
 func (c *Command) Action(action Action) *Command {
+	if action == nil {
+		c.actionCallback = nil
+		return c
+	}
+	c.actionCallback = func(context.Context, *Command, []string) error {
+		return action()
+	}
+	return c
+}
+
+// ActionContext sets a context-aware action callback for the command.
+// It replaces any callback set with Action.
+func (c *Command) ActionContext(action ActionContext) *Command {
 	c.actionCallback = action
 	return c
 }
 
+// Context returns the context of the current run.
+// It returns context.Background() when the command is not running.
+func (c *Command) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 // NewSubCommand creates a new subcommand under the current command.
 // The subcommand name should be unique within the current command.
 // The description should be a concise summary of what the subcommand does.
@@ -99,11 +165,30 @@ func (c *Command) NewSubCommand(name string, description string) *Command {
 // addSubCommand adds a subcommand to the current command.
 // This method is used internally to manage subcommands.
 func (c *Command) addSubCommand(cmd *Command) {
+	cmd.parent = c
+	cmd.attach(c)
 	c.subCommands = append(c.subCommands, cmd)
 	c.subCommandsMap[cmd.name] = cmd
 	if len(cmd.name) > c.longestSubcommand {
 		c.longestSubcommand = len(cmd.name)
 	}
+	c.registerAliases(cmd, cmd.aliases)
+	cmd.checkConfigFlag()
+}
+
+// attach sets the application and command path of a command added to parent,
+// and of its subcommands, so that commands built with NewCommand share the
+// output, input and exit function of the application.
+func (c *Command) attach(parent *Command) {
+	c.app = parent.app
+	c.commandPath = c.name
+	if parent.commandPath != "" {
+		c.commandPath = parent.commandPath + " " + c.name
+	}
+	c.flags.Init(c.commandPath, flag.ContinueOnError)
+	for _, sub := range c.subCommands {
+		sub.attach(c)
+	}
 }
 
 // Hidden marks the command as hidden.
@@ -114,37 +199,12 @@ func (c *Command) Hidden(hidden bool) *Command {
 }
 
 // PrintHelp displays the help text for the command.
-// The help text includes the command description, flags, and subcommands.
-// Subcommands are only shown if the command has any subcommands.
+// The help text is rendered from the command's help template, the
+// application's template or DefaultHelpTemplate, in that order.
 func (c *Command) PrintHelp() {
-	fmt.Printf("%s\n\n", c.commandPath)
-	fmt.Printf("%s\n\n", c.shortdescription)
-
-	if len(c.subCommands) > 0 {
-		fmt.Printf("Commands:\n\n")
-		for _, cmd := range c.subCommands {
-			if cmd.hidden {
-				continue
-			}
-			if cmd.shortdescription != "" {
-				fmt.Printf("  %-*s %s\n", c.longestSubcommand, cmd.name, cmd.shortdescription)
-			} else {
-				fmt.Printf("  %-*s\n", c.longestSubcommand, cmd.name)
-			}
-		}
-		fmt.Printf("\n")
+	if err := c.writeHelp(c.Output()); err != nil {
+		fmt.Fprintf(c.ErrOutput(), "Error printing help: %v\n", err)
 	}
-
-	fmt.Printf("Flags:\n\n")
-	c.flags.VisitAll(func(f *flag.Flag) {
-		fmt.Printf("  -%s", f.Name)
-		usage := f.Usage
-		if usage != "" {
-			fmt.Printf(" %s", usage)
-		}
-		fmt.Printf("\n")
-	})
-	fmt.Printf("\n")
 }
 
 // SetName sets the name of the command.
@@ -211,7 +271,7 @@ func (c *Command) NewSubCommandInheritFlags(name string, description string) *Co
 	command := NewCommand(name, description)
 	command.setApp(c.app)
 	command.setParentCommandPath(c.commandPath)
-	command.inheritFlags(c.flags)
+	command.inheritFlags(c)
 	c.addSubCommand(command)
 	return command
 }
@@ -266,6 +326,12 @@ func (c *Command) IsHidden() bool {
 	return c.hidden
 }
 
+// OtherArgs returns the non-flag arguments passed to the command.
+// This should only be called within the context of an action callback.
+func (c *Command) OtherArgs() []string {
+	return c.positionalArgs
+}
+
 // IsHelpRequested returns whether the help flag was requested.
 // This method can be used to determine if the command should display help.
 func (c *Command) IsHelpRequested() bool {
@@ -280,37 +346,56 @@ func (c *Command) App() *Cli {
 
 // ExitWithError prints the error message and exits with a non-zero status code.
 // This method should be used to display error messages to the user.
-// The error message is printed to stderr.
+// The error message is printed to the error writer and the exit code is
+// derived from the error with ExitCodeOf.
 func (c *Command) ExitWithError(err error) {
-	fmt.Fprintf(os.Stderr, "%v\n", err)
-	os.Exit(1)
+	fmt.Fprintf(c.ErrOutput(), "%v\n", err)
+	c.exit(ExitCodeOf(err))
 }
 
 // Exit prints the message and exits with a zero status code.
 // This method should be used to display messages to the user without an error.
-// The message is printed to stdout.
+// The message is printed to the output writer.
 func (c *Command) Exit(message string) {
-	fmt.Println(message)
-	os.Exit(0)
+	fmt.Fprintln(c.Output(), message)
+	c.exit(ExitCodeOK)
+}
+
+// run executes the Command with the given arguments and a background context.
+func (c *Command) run(args []string) error {
+	return c.execute(context.Background(), args)
 }
 
-// run executes the Command with the given arguments.
+// execute runs the Command with the given context and arguments.
 // This method handles flag parsing, subcommand execution, and action invocation.
 // If a subcommand is specified, it delegates execution to that subcommand.
 // If the help flag is requested, it displays the help text and exits.
 // If an action callback is defined, it executes that callback.
 // Returns an error if any step of the execution fails.
-func (c *Command) run(args []string) error {
+func (c *Command) execute(ctx context.Context, args []string) error {
+	// The config flag may appear before or after the subcommand, so it is
+	// removed before the subcommand is looked up
+	var configPath string
+	configOK := true
+	if c.app != nil && c.app.configFlag != "" {
+		args, configPath, configOK = extractFlag(args, c.app.configFlag)
+	}
+
 	// Check for help flag before parsing flags
 	command := c
 	command_args := args
+	next := 0 // index of the argument directly following the current command
 	for i, arg := range args {
-		subcommand := command.subCommandsMap[arg]
+		subcommand, err := command.lookupSubCommand(arg, i == next)
+		if err != nil {
+			return command.handleError(err)
+		}
 		if subcommand != nil {
 			command = subcommand
 			command_args = args[i+1:]
+			next = i + 1
 		}
-		if arg == "--help" || arg == "-h" {
+		if arg == "--help" || (arg == "-h" && command.shortFlags["h"] == "") {
 			command.helpFlag = true
 		}
 	}
@@ -319,18 +404,31 @@ func (c *Command) run(args []string) error {
 		command.PrintHelp()
 		return nil
 	}
+	// Commands that only group subcommands reject unknown ones
+	if command.app != nil && command.app.strictSubcommands && command.actionCallback == nil &&
+		len(command.subCommands) > 0 && next < len(args) && !strings.HasPrefix(args[next], "-") {
+		return command.handleError(command.unknownCommand(args[next]))
+	}
+	// Load config files
+	if !configOK {
+		return command.handleError(&MissingValueError{Command: command.commandPath, Flag: c.app.configFlag})
+	}
+	if command.app != nil {
+		if err := command.app.loadConfig(configPath); err != nil {
+			return command.handleError(err)
+		}
+	}
+
 	// Parse flags
 	err := command.parseFlags(command_args)
 	if err != nil {
-		if command.app != nil && command.app.errorHandler != nil {
-			return command.app.errorHandler(command.commandPath, err)
-		}
-		return err
+		return command.handleError(err)
 	}
 
 	// If we have a subcommand, run it
 	if command.actionCallback != nil {
-		return command.actionCallback()
+		command.ctx = ctx
+		return command.handler()(ctx, command, command.positionalArgs)
 	}
 
 	// If we haven't specified a subcommand
@@ -340,7 +438,7 @@ func (c *Command) run(args []string) error {
 		if command.app.defaultCommand != command {
 			// only run default command if no args passed
 			if len(command_args) == 0 {
-				return command.app.defaultCommand.run(command_args)
+				return command.app.defaultCommand.execute(ctx, command_args)
 			}
 		}
 	}
@@ -353,3 +451,9 @@ func (c *Command) run(args []string) error {
 func (c *Command) Run(args ...string) error {
 	return c.run(args)
 }
+
+// RunContext executes the Command with the given context and arguments.
+// The context is passed to ActionContext callbacks and returned by Context.
+func (c *Command) RunContext(ctx context.Context, args ...string) error {
+	return c.execute(ctx, args)
+}
@@ -0,0 +1,309 @@
+package cliz
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// completeCommandName is the hidden command the completion scripts call to
+// get candidates, e.g. "myapp __complete server --for" for "myapp server --for<TAB>".
+const completeCommandName = "__complete"
+
+// Completion is a completion candidate.
+type Completion struct {
+	Value       string              // Text inserted on the command line
+	Description string              // Optional description shown by shells that support it
+	Directive   CompletionDirective // Directive for the shell; see the Complete* helpers
+}
+
+// CompletionDirective tells the shell how to complete when the candidates are
+// not enough. Directives of all returned completions are combined.
+type CompletionDirective int
+
+const (
+	// DirectiveDefault lets the shell fall back to file completion when there
+	// are no candidates.
+	DirectiveDefault CompletionDirective = 0
+	// DirectiveNoFileComp disables the fallback to file completion.
+	DirectiveNoFileComp CompletionDirective = 1
+	// DirectiveFilterFileExt completes files with the extensions given as the
+	// values of the completions, and directories.
+	DirectiveFilterFileExt CompletionDirective = 2
+	// DirectiveFilterDirs completes directories only.
+	DirectiveFilterDirs CompletionDirective = 4
+)
+
+// CompletionFunc returns the completions for a partially typed argument.
+// Returned values do not need to be filtered by partial; shells do that.
+type CompletionFunc func(ctx context.Context, partial string) []Completion
+
+// NoFileCompletions returns a directive-only completion that stops the shell
+// from completing file names. It can be appended to other completions.
+func NoFileCompletions() []Completion {
+	return []Completion{{Directive: DirectiveNoFileComp}}
+}
+
+// FileExtCompletions returns completions that make the shell complete only
+// files with the given extensions, e.g. FileExtCompletions("yaml", "yml").
+// They should not be combined with other completions.
+func FileExtCompletions(extensions ...string) []Completion {
+	completions := make([]Completion, len(extensions))
+	for i, ext := range extensions {
+		completions[i] = Completion{Value: strings.TrimPrefix(ext, "."), Directive: DirectiveFilterFileExt}
+	}
+	return completions
+}
+
+// DirCompletions returns a directive-only completion that makes the shell
+// complete directory names only.
+func DirCompletions() []Completion {
+	return []Completion{{Directive: DirectiveFilterDirs}}
+}
+
+// CompleteFlag sets the function used to complete values of a flag.
+// Without one, flag values are completed from In validators or as file names.
+// It panics if the flag is not defined.
+func (c *Command) CompleteFlag(flagName string, fn CompletionFunc) *Command {
+	if c.flags.Lookup(flagName) == nil {
+		panic("CompleteFlag: flag '" + flagName + "' is not defined")
+	}
+	c.flagCompletions[flagName] = fn
+	return c
+}
+
+// CompletePositional sets the function used to complete the positional
+// argument at index, counting from 0.
+func (c *Command) CompletePositional(index int, fn CompletionFunc) *Command {
+	c.positionalCompletions[index] = fn
+	return c
+}
+
+// runComplete handles the hidden __complete command. The last argument is the
+// word being completed; the candidates are written one per line as
+// "value<TAB>description", followed by ":<directive>".
+func (c *Cli) runComplete(ctx context.Context, w io.Writer, args []string) error {
+	completions, directive := c.rootCommand.complete(ctx, args)
+	var b strings.Builder
+	for _, completion := range completions {
+		b.WriteString(completion.Value)
+		if completion.Description != "" {
+			b.WriteString("\t" + strings.ReplaceAll(completion.Description, "\n", " "))
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, ":%d\n", directive)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// complete returns the candidates for the last of args, which are the words
+// after the application name.
+func (c *Command) complete(ctx context.Context, args []string) ([]Completion, CompletionDirective) {
+	partial := ""
+	if len(args) > 0 {
+		partial = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+
+	// Find the command being completed, then the state after its arguments
+	command := c
+	start := 0
+	for i, arg := range args {
+		if sub, _ := command.lookupSubCommand(arg, false); sub != nil && !sub.hidden {
+			command = sub
+			start = i + 1
+		}
+	}
+	var valueFlag *flag.Flag
+	configValue := false
+	positional := 0
+	flagsDone := false
+	for _, arg := range args[start:] {
+		switch {
+		case valueFlag != nil || configValue:
+			valueFlag, configValue = nil, false
+		case flagsDone || len(arg) < 2 || arg[0] != '-':
+			positional++
+		case arg == "--":
+			flagsDone = true
+		case c.app != nil && c.app.configFlag != "" && strings.TrimLeft(arg, "-") == c.app.configFlag:
+			configValue = true
+		default:
+			valueFlag = command.pendingValueFlag(arg)
+		}
+	}
+
+	if valueFlag != nil {
+		return command.completeFlagValue(ctx, valueFlag, "", partial)
+	}
+	if configValue {
+		return nil, DirectiveDefault
+	}
+	if !flagsDone && strings.HasPrefix(partial, "-") {
+		if name, value, ok := strings.Cut(strings.TrimLeft(partial, "-"), "="); ok {
+			if f := command.lookupFlag(name); f != nil {
+				return command.completeFlagValue(ctx, f, partial[:len(partial)-len(value)], value)
+			}
+			return nil, DirectiveNoFileComp
+		}
+		return command.completeFlagNames(partial), DirectiveNoFileComp
+	}
+
+	var completions []Completion
+	for _, sub := range command.subCommands {
+		if sub.hidden {
+			continue
+		}
+		for _, name := range append([]string{sub.name}, sub.aliases...) {
+			if strings.HasPrefix(name, partial) {
+				completions = append(completions, Completion{Value: name, Description: sub.shortdescription})
+			}
+		}
+	}
+	if fn, ok := command.positionalCompletions[positional]; ok {
+		return mergeCompletions(completions, fn(ctx, partial))
+	}
+	if arg := command.positionalAt(positional); arg != nil {
+		if values := allowedValues(arg.validators); len(values) > 0 {
+			for _, value := range values {
+				if strings.HasPrefix(value, partial) {
+					completions = append(completions, Completion{Value: value, Description: arg.description})
+				}
+			}
+			return completions, DirectiveNoFileComp
+		}
+	}
+	if len(command.subCommands) > 0 {
+		return completions, DirectiveNoFileComp
+	}
+	return completions, DirectiveDefault
+}
+
+// lookupFlag returns the flag with the given long or short name.
+func (c *Command) lookupFlag(name string) *flag.Flag {
+	if f := c.flags.Lookup(name); f != nil {
+		return f
+	}
+	if long, ok := c.shortFlags[name]; ok {
+		return c.flags.Lookup(long)
+	}
+	return nil
+}
+
+// pendingValueFlag returns the flag that takes the next argument as its
+// value, if arg is such a flag given without a value.
+func (c *Command) pendingValueFlag(arg string) *flag.Flag {
+	if strings.Contains(arg, "=") {
+		return nil
+	}
+	var f *flag.Flag
+	if strings.HasPrefix(arg, "--") {
+		f = c.flags.Lookup(arg[2:])
+	} else if f = c.flags.Lookup(arg[1:]); f == nil {
+		// In a group of short flags, only a non-boolean flag at the end
+		// takes the next argument; earlier ones take the rest of the group
+		group := []rune(arg[1:])
+		for i, r := range group {
+			sf := c.lookupFlag(string(r))
+			if sf == nil {
+				return nil
+			}
+			if !isBoolFlag(sf.Value) {
+				if i < len(group)-1 {
+					return nil
+				}
+				f = sf
+			}
+		}
+	}
+	if f == nil || isBoolFlag(f.Value) {
+		return nil
+	}
+	return f
+}
+
+// completeFlagValue completes the value of a flag. The prefix is prepended to
+// every candidate, for values given as --name=value.
+func (c *Command) completeFlagValue(ctx context.Context, f *flag.Flag, prefix, partial string) ([]Completion, CompletionDirective) {
+	if fn, ok := c.flagCompletions[f.Name]; ok {
+		completions, directive := mergeCompletions(nil, fn(ctx, partial))
+		if directive&DirectiveFilterFileExt == 0 {
+			for i := range completions {
+				completions[i].Value = prefix + completions[i].Value
+			}
+		}
+		return completions, directive
+	}
+	values := allowedValues(c.flagValidations[f.Name])
+	if len(values) == 0 {
+		return nil, DirectiveDefault
+	}
+	var completions []Completion
+	for _, value := range values {
+		if strings.HasPrefix(value, partial) {
+			completions = append(completions, Completion{Value: prefix + value})
+		}
+	}
+	return completions, DirectiveNoFileComp
+}
+
+// completeFlagNames completes the long and short names of the command's flags.
+func (c *Command) completeFlagNames(partial string) []Completion {
+	var completions []Completion
+	add := func(name, description string) {
+		if strings.HasPrefix(name, partial) {
+			completions = append(completions, Completion{Value: name, Description: description})
+		}
+	}
+	c.flags.VisitAll(func(f *flag.Flag) {
+		add("--"+f.Name, f.Usage)
+	})
+	if c.app != nil && c.app.configFlag != "" {
+		add("--"+c.app.configFlag, "Load configuration from file.")
+	}
+	shorts := make([]string, 0, len(c.shortFlags))
+	for short := range c.shortFlags {
+		shorts = append(shorts, short)
+	}
+	sort.Strings(shorts)
+	for _, short := range shorts {
+		add("-"+short, c.flags.Lookup(c.shortFlags[short]).Usage)
+	}
+	return completions
+}
+
+// mergeCompletions appends the completions returned by a callback to
+// completions and combines their directives. Directive-only entries are
+// dropped, and when files are filtered by extension only the extensions are
+// kept. Candidates disable the fallback to file completion.
+func mergeCompletions(completions, results []Completion) ([]Completion, CompletionDirective) {
+	directive := DirectiveDefault
+	for _, result := range results {
+		directive |= result.Directive
+	}
+	switch {
+	case directive&DirectiveFilterDirs != 0:
+		return nil, directive
+	case directive&DirectiveFilterFileExt != 0:
+		var extensions []Completion
+		for _, result := range results {
+			if result.Directive&DirectiveFilterFileExt != 0 && result.Value != "" {
+				extensions = append(extensions, result)
+			}
+		}
+		return extensions, directive
+	}
+	for _, result := range results {
+		if result.Value != "" {
+			completions = append(completions, result)
+		}
+	}
+	if len(completions) > 0 {
+		directive |= DirectiveNoFileComp
+	}
+	return completions, directive
+}
@@ -0,0 +1,140 @@
+package cliz
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureStdout returns what fn writes to standard output.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return string(out)
+}
+
+func newCompleteTestCli() *Cli {
+	cli := NewCli("myapp", "test description", "1.0.0")
+	var format, config, dir, cluster string
+	var verbose bool
+	cli.String("format", "Output format", &format, In("json", "yaml")).Alias("format", "f")
+	cli.Bool("verbose", "Verbose output", &verbose).Alias("verbose", "v")
+	server := cli.NewSubCommand("server", "Run the server").Aliases("srv")
+	server.String("config", "Config file", &config).CompleteFlag("config", func(ctx context.Context, partial string) []Completion {
+		return FileExtCompletions("yaml", ".yml")
+	})
+	server.String("dir", "Data directory", &dir).CompleteFlag("dir", func(ctx context.Context, partial string) []Completion {
+		return DirCompletions()
+	})
+	server.String("cluster", "Cluster name", &cluster).CompleteFlag("cluster", func(ctx context.Context, partial string) []Completion {
+		return []Completion{{Value: "prod", Description: "Production"}, {Value: "staging"}}
+	})
+	server.CompletePositional(1, func(ctx context.Context, partial string) []Completion {
+		return append([]Completion{{Value: partial + "-target"}}, NoFileCompletions()...)
+	})
+	cli.NewSubCommand("secret", "Hidden command").Hidden(true)
+	return cli.EnableCompletion()
+}
+
+func complete(t *testing.T, cli *Cli, args ...string) string {
+	t.Helper()
+	return captureStdout(t, func() {
+		err := cli.Run(append([]string{"__complete"}, args...)...)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+}
+
+func TestCompleteSubcommands(t *testing.T) {
+	cli := newCompleteTestCli()
+	out := complete(t, cli, "s")
+	expected := "server\tRun the server\nsrv\tRun the server\n:1\n"
+	if out != expected {
+		t.Fatalf("Expected %q, got %q", expected, out)
+	}
+}
+
+func TestCompleteFlagNames(t *testing.T) {
+	cli := newCompleteTestCli()
+	out := complete(t, cli, "--f")
+	expected := "--format\tOutput format\n:1\n"
+	if out != expected {
+		t.Fatalf("Expected %q, got %q", expected, out)
+	}
+	out = complete(t, cli, "-")
+	if !strings.Contains(out, "-f\tOutput format\n") || !strings.Contains(out, "-v\tVerbose output\n") {
+		t.Fatalf("Expected short flags in %q", out)
+	}
+}
+
+func TestCompleteFlagValues(t *testing.T) {
+	cli := newCompleteTestCli()
+	if out := complete(t, cli, "-vf", ""); out != "json\nyaml\n:1\n" {
+		t.Fatalf("Expected In values, got %q", out)
+	}
+	if out := complete(t, cli, "--format=y"); out != "--format=yaml\n:1\n" {
+		t.Fatalf("Expected In values with prefix, got %q", out)
+	}
+	if out := complete(t, cli, "srv", "--cluster", "p"); out != "prod\tProduction\nstaging\n:1\n" {
+		t.Fatalf("Expected callback values, got %q", out)
+	}
+}
+
+func TestCompleteDirectives(t *testing.T) {
+	cli := newCompleteTestCli()
+	if out := complete(t, cli, "server", "--config", ""); out != "yaml\nyml\n:2\n" {
+		t.Fatalf("Expected extension filter, got %q", out)
+	}
+	if out := complete(t, cli, "server", "--dir", ""); out != ":4\n" {
+		t.Fatalf("Expected directories only, got %q", out)
+	}
+	if out := complete(t, cli, "server", "--cluster", "prod", "first", "x"); out != "x-target\n:1\n" {
+		t.Fatalf("Expected positional completion, got %q", out)
+	}
+	if out := complete(t, cli, "server", "first", "second", ""); out != ":0\n" {
+		t.Fatalf("Expected file completion, got %q", out)
+	}
+}
+
+func TestCompleteSkipsHiddenCommands(t *testing.T) {
+	cli := newCompleteTestCli()
+	if out := complete(t, cli, "sec"); out != ":1\n" {
+		t.Fatalf("Expected hidden command not to be completed, got %q", out)
+	}
+}
+
+func TestCompletePositionalAllowedValues(t *testing.T) {
+	type Args struct {
+		Action string   `position:"0" description:"Service action" validate:"in=start|stop|status"`
+		Units  []string `position:"1..." validate:"required"`
+	}
+	var args Args
+	cli := NewCli("myapp", "test description", "1.0.0")
+	cli.NewSubCommand("service", "Control services").AddPositionalArgs(&args)
+	cli.EnableCompletion()
+
+	out := complete(t, cli, "service", "st")
+	expected := "start\tService action\nstop\tService action\nstatus\tService action\n:1\n"
+	if out != expected {
+		t.Fatalf("Expected %q, got %q", expected, out)
+	}
+	out = complete(t, cli, "service", "start", "")
+	if out != ":0\n" {
+		t.Fatalf("Expected default completion for units, got %q", out)
+	}
+}
@@ -0,0 +1,312 @@
+package cliz
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/zkep/cliz/validator"
+)
+
+// completionShells lists the shells completion scripts can be generated for.
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// EnableCompletion adds a "completion" subcommand to the root command that
+// prints a shell completion script, e.g. "myapp completion bash".
+// The scripts call back into the application through a hidden "__complete"
+// command, which completes subcommand names and aliases, flag names, the values
+// allowed by In validators and anything set with CompleteFlag or
+// CompletePositional. Hidden commands are not completed.
+func (c *Cli) EnableCompletion() *Cli {
+	c.completion = true
+	completion := c.NewSubCommand("completion", "Generate a shell completion script.")
+	completion.SetLongDescription("Prints a completion script for the given shell. For example:\n\n" +
+		"  source <(" + c.Name() + " completion bash)")
+	for _, shell := range completionShells {
+		completion.NewSubCommand(shell, "Generate the completion script for "+shell+".").
+			ActionContext(func(ctx context.Context, cmd *Command, args []string) error {
+				return c.WriteCompletion(cmd.Output(), shell)
+			})
+	}
+	return c
+}
+
+// WriteCompletion writes the completion script for the given shell to w.
+// Supported shells are bash, zsh, fish and powershell.
+func (c *Cli) WriteCompletion(w io.Writer, shell string) error {
+	name := c.Name()
+	switch shell {
+	case "bash":
+		return writeBashCompletion(w, name)
+	case "zsh":
+		return writeZshCompletion(w, name)
+	case "fish":
+		return writeFishCompletion(w, name)
+	case "powershell":
+		return writePowerShellCompletion(w, name)
+	}
+	return fmt.Errorf("unsupported shell %q, expected one of: %s", shell, strings.Join(completionShells, ", "))
+}
+
+// allowedValues returns the values accepted by the In validators in validators.
+func allowedValues(validators []Validator) []string {
+	var values []string
+	for _, v := range validators {
+		wrapper, ok := v.(validatorWrapper)
+		if !ok {
+			continue
+		}
+		if in, ok := wrapper.externalValidator.(*validator.InValidator); ok {
+			values = append(values, in.Allowed...)
+		}
+	}
+	return values
+}
+
+// functionName turns the application name into a shell function name.
+func functionName(name string) string {
+	return "_" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// powerShellQuote quotes s as a PowerShell literal string.
+func powerShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// The scripts below call "<app> __complete <words...> <current word>" and
+// read one "value<TAB>description" candidate per line, followed by a
+// ":<directive>" line. The directive bits match CompletionDirective.
+
+// writeBashCompletion writes a bash completion function.
+func writeBashCompletion(w io.Writer, name string) error {
+	fn := functionName(name)
+	_, err := fmt.Fprintf(w, `# bash completion for %[1]s
+
+%[2]s() {
+    # Keep words such as --flag=value whole, although COMP_WORDBREAKS splits them at = and :
+    local cur words cword
+    if declare -F _get_comp_words_by_ref >/dev/null 2>&1; then
+        _get_comp_words_by_ref -n =: cur words cword
+    else
+        local line="${COMP_LINE:0:COMP_POINT}"
+        read -ra words <<< "$line"
+        [[ "$line" == *[[:space:]] || ${#words[@]} -eq 0 ]] && words+=("")
+        cword=$(( ${#words[@]} - 1 ))
+        cur="${words[cword]}"
+    fi
+
+    # Bash only replaces the part of the word after the last break character
+    local prefix="" breaks=""
+    [[ "$COMP_WORDBREAKS" == *=* ]] && breaks+="="
+    [[ "$COMP_WORDBREAKS" == *:* ]] && breaks+=":"
+    if [[ -n "$breaks" && "$cur" == *["$breaks"]* ]]; then
+        prefix="${cur%%"${cur##*["$breaks"]}"}"
+    fi
+    local word="${cur#"$prefix"}"
+
+    local IFS=$'\n'
+    local out
+    out=$("${words[0]}" %[3]s "${words[@]:1:cword-1}" "$cur" 2>/dev/null) || return
+
+    local -a values=()
+    local line directive=0
+    while IFS= read -r line; do
+        case "$line" in
+            :*) directive=${line#:} ;;
+            *) values+=("${line%%%%$'\t'*}") ;;
+        esac
+    done <<< "$out"
+
+    if (( directive & 4 )); then
+        compopt -o filenames 2>/dev/null
+        COMPREPLY=($(compgen -d -- "$word"))
+        return
+    fi
+    if (( directive & 2 )); then
+        compopt -o filenames 2>/dev/null
+        local file ext
+        COMPREPLY=()
+        while IFS= read -r file; do
+            if [[ -d "$file" ]]; then
+                COMPREPLY+=("$file")
+                continue
+            fi
+            for ext in "${values[@]}"; do
+                [[ "$file" == *."$ext" ]] && COMPREPLY+=("$file") && break
+            done
+        done < <(compgen -f -- "$word")
+        return
+    fi
+    if (( directive & 1 )); then
+        compopt +o default 2>/dev/null
+    fi
+    COMPREPLY=($(compgen -W "${values[*]}" -- "$cur"))
+    COMPREPLY=("${COMPREPLY[@]#"$prefix"}")
+}
+
+complete -o default -F %[2]s %[1]s
+`, name, fn, completeCommandName)
+	return err
+}
+
+// writeZshCompletion writes a zsh completion function with descriptions.
+func writeZshCompletion(w io.Writer, name string) error {
+	fn := functionName(name)
+	_, err := fmt.Fprintf(w, `#compdef %[1]s
+
+%[2]s() {
+    local out
+    out=$("${words[1]}" %[3]s "${(@)words[2,CURRENT-1]}" "${words[CURRENT]}" 2>/dev/null) || return
+
+    local -a lines candidates
+    local line value directive=0
+    lines=("${(@f)out}")
+    for line in "${lines[@]}"; do
+        if [[ "$line" == :* ]]; then
+            directive=${line#:}
+            continue
+        fi
+        value=${line%%%%$'\t'*}
+        if [[ "$line" == *$'\t'* ]]; then
+            candidates+=("${value//:/\\:}:${line#*$'\t'}")
+        else
+            candidates+=("${value//:/\\:}")
+        fi
+    done
+
+    if (( directive & 4 )); then
+        _files -/
+        return
+    fi
+    if (( directive & 2 )); then
+        local -a extensions
+        for line in "${candidates[@]}"; do
+            extensions+=("${line%%%%:*}")
+        done
+        _files -g "*.(${(j:|:)extensions})"
+        return
+    fi
+    if (( ${#candidates} > 0 )); then
+        _describe 'completions' candidates
+        return
+    fi
+    if (( !(directive & 1) )); then
+        _files
+    fi
+}
+
+compdef %[2]s %[1]s
+`, name, fn, completeCommandName)
+	return err
+}
+
+// writeFishCompletion writes a fish completion function.
+func writeFishCompletion(w io.Writer, name string) error {
+	fn := "_" + functionName(name)
+	_, err := fmt.Fprintf(w, `# fish completion for %[1]s
+
+function %[2]s
+    set -l words (commandline -opc)
+    set -l token (commandline -ct)
+    set -l cmd $words[1]
+    set -e words[1]
+    set -l lines ($cmd %[3]s $words $token 2>/dev/null)
+    or return
+
+    set -l directive 0
+    set -l candidates
+    for line in $lines
+        if string match -q -- ':*' $line
+            set directive (string sub -s 2 -- $line)
+        else
+            set -a candidates $line
+        end
+    end
+
+    if test (math "bitand($directive, 4)") -ne 0
+        __fish_complete_directories $token
+        return
+    end
+    if test (math "bitand($directive, 2)") -ne 0
+        set -l pattern '\.('(string join '|' (string escape --style=regex -- $candidates))')$'
+        for file in $token*
+            if test -d $file
+                echo $file/
+            else if string match -q -r -- $pattern $file
+                echo $file
+            end
+        end
+        return
+    end
+    printf '%%s\n' $candidates
+    if test (count $candidates) -eq 0; and test (math "bitand($directive, 1)") -eq 0
+        __fish_complete_path $token
+    end
+end
+
+complete -c %[1]s -e
+complete -c %[1]s -f -a '(%[2]s)'
+`, name, fn, completeCommandName)
+	return err
+}
+
+// writePowerShellCompletion writes a native argument completer.
+func writePowerShellCompletion(w io.Writer, name string) error {
+	_, err := fmt.Fprintf(w, `# powershell completion for %[1]s
+
+Register-ArgumentCompleter -Native -CommandName %[2]s -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $words = @($commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() })
+    if ($wordToComplete -ne '' -and $words.Count -gt 0) {
+        $words = @($words | Select-Object -SkipLast 1)
+    }
+    # Older PowerShell versions drop empty arguments to native commands
+    if ($wordToComplete -eq '' -and $PSVersionTable.PSVersion -lt [version]'7.3') {
+        $words += '""'
+    } else {
+        $words += $wordToComplete
+    }
+    $lines = @(& %[2]s %[3]s @words 2>$null)
+    if ($lines.Count -eq 0) { return }
+
+    $directive = 0
+    $candidates = @()
+    foreach ($line in $lines) {
+        if ($line.StartsWith(':')) {
+            $directive = [int]$line.Substring(1)
+        } else {
+            $candidates += ,($line -split "`+"`"+`t", 2)
+        }
+    }
+
+    if ($directive -band 6) {
+        $extensions = @($candidates | ForEach-Object { '.' + $_[0] })
+        $parent = Split-Path -Path $wordToComplete
+        Get-ChildItem -Path "$wordToComplete*" -ErrorAction SilentlyContinue |
+            Where-Object { $_.PSIsContainer -or (($directive -band 2) -and $extensions -contains $_.Extension) } |
+            ForEach-Object {
+                $path = if ($parent) { Join-Path $parent $_.Name } else { $_.Name }
+                [System.Management.Automation.CompletionResult]::new($path, $path, 'ProviderItem', $path)
+            }
+        return
+    }
+    $results = @($candidates | Where-Object { $_[0] -like "$wordToComplete*" } | ForEach-Object {
+        $description = if ($_.Count -gt 1) { $_[1] } else { $_[0] }
+        [System.Management.Automation.CompletionResult]::new($_[0], $_[0], 'ParameterValue', $description)
+    })
+    if ($results.Count -gt 0) {
+        $results
+    } elseif ($directive -band 1) {
+        ''
+    }
+}
+`, name, powerShellQuote(name), completeCommandName)
+	return err
+}
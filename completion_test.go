@@ -0,0 +1,55 @@
+package cliz
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteCompletion(t *testing.T) {
+	cli := NewCli("my-app", "test description", "1.0.0").EnableCompletion()
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		var b strings.Builder
+		err := cli.WriteCompletion(&b, shell)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		script := b.String()
+		if !strings.Contains(script, "__complete") {
+			t.Fatalf("Expected %s script to call __complete", shell)
+		}
+		if !strings.Contains(script, "my-app") {
+			t.Fatalf("Expected %s script to register my-app", shell)
+		}
+	}
+}
+
+func TestBashCompletionScript(t *testing.T) {
+	cli := NewCli("my-app", "test description", "1.0.0").EnableCompletion()
+	var b strings.Builder
+	err := cli.WriteCompletion(&b, "bash")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	script := b.String()
+	for _, expected := range []string{
+		"_my_app() {",
+		`values+=("${line%%$'\t'*}")`,
+		"complete -o default -F _my_app my-app",
+		"_get_comp_words_by_ref -n =: cur words cword",
+		`local line="${COMP_LINE:0:COMP_POINT}"`,
+		`COMPREPLY=("${COMPREPLY[@]#"$prefix"}")`,
+	} {
+		if !strings.Contains(script, expected) {
+			t.Fatalf("Expected bash script to contain %q, got:\n%s", expected, script)
+		}
+	}
+}
+
+func TestWriteCompletionUnsupportedShell(t *testing.T) {
+	cli := NewCli("my-app", "test description", "1.0.0").EnableCompletion()
+	var b strings.Builder
+	err := cli.WriteCompletion(&b, "tcsh")
+	if err == nil {
+		t.Fatalf("Expected an error for an unsupported shell")
+	}
+}
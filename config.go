@@ -0,0 +1,280 @@
+package cliz
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// configFile is a loaded configuration file.
+// Structured formats (JSON, YAML, TOML) are stored as a tree of nested maps
+// whose leaves are strings or lists; .env files are stored as flat variables.
+type configFile struct {
+	path   string
+	values map[string]any
+	dotenv bool
+}
+
+// ConfigFile sets the configuration files loaded before flags are parsed.
+// Files that do not exist are skipped. When several files exist they are all
+// loaded in order, with later files overriding earlier ones.
+// The format is chosen from the file extension: .json, .yaml/.yml, .toml or .env.
+// YAML and TOML files are limited to sections, scalars and single level lists;
+// multi-line strings, inline tables, nested lists, anchors and similar syntax
+// are reported as errors rather than guessed at.
+// Keys are mapped onto flag names; subcommand flags live in nested sections
+// named after the command path, e.g. [server.start] for "myapp server start".
+// Values from .env files are matched against the flag's environment variable names.
+// Precedence is default < config file < environment < command line.
+func (c *Cli) ConfigFile(paths ...string) *Cli {
+	c.configFiles = paths
+	return c
+}
+
+// ConfigFlag enables a flag, available on every command, that names the
+// configuration file to load instead of the ConfigFile paths.
+// The flag may be given before or after the subcommand, e.g. both
+// "myapp --config x.yaml server" and "myapp server --config x.yaml" work.
+// Unlike the default paths, a file given with the flag must exist.
+// It panics if a command already has a flag or short flag with that name,
+// and adding such a flag later panics as well.
+func (c *Cli) ConfigFlag(name string) *Cli {
+	c.configFlag = name
+	c.rootCommand.checkConfigFlag()
+	return c
+}
+
+// checkConfigFlag panics if the command or one of its subcommands defines a
+// flag that would be taken as the config flag.
+func (c *Command) checkConfigFlag() {
+	if c.app == nil || c.app.configFlag == "" {
+		return
+	}
+	name := c.app.configFlag
+	if c.flags.Lookup(name) != nil || c.shortFlags[name] != "" {
+		panic("flag '" + name + "' of command '" + c.commandPath + "' conflicts with the config flag")
+	}
+	for _, sub := range c.subCommands {
+		sub.checkConfigFlag()
+	}
+}
+
+// loadConfig loads the configuration files, or only the file at path if it is
+// not empty, as given with the config flag.
+func (c *Cli) loadConfig(path string) error {
+	c.configs = nil
+	paths := c.configFiles
+	explicit := path != ""
+	if explicit {
+		paths = []string{path}
+	}
+
+	for _, path := range paths {
+		config, err := readConfigFile(path)
+		if err != nil {
+			if !explicit && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		c.configs = append(c.configs, config)
+	}
+	return nil
+}
+
+// extractFlag removes every occurrence of a string flag from args and
+// returns the remaining arguments along with the last value given.
+// It reports false if the flag is the last argument and has no value,
+// in which case the arguments before it are returned.
+func extractFlag(args []string, name string) ([]string, string, bool) {
+	var rest []string
+	var value string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		trimmed := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if trimmed == arg {
+			rest = append(rest, arg)
+			continue
+		}
+		if v, ok := strings.CutPrefix(trimmed, name+"="); ok {
+			value = v
+			continue
+		}
+		if trimmed == name {
+			if i+1 >= len(args) {
+				return rest, "", false
+			}
+			value = args[i+1]
+			i++
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, value, true
+}
+
+// readConfigFile reads and parses a configuration file based on its extension.
+func readConfigFile(path string) (*configFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &configFile{path: path}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = parseJSONConfig(data, &config.values)
+	case ".yaml", ".yml":
+		config.values, err = parseYAMLConfig(string(data))
+	case ".toml":
+		config.values, err = parseTOMLConfig(string(data))
+	case ".env":
+		config.values, err = parseDotEnvConfig(string(data))
+		config.dotenv = true
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %v", path, err)
+	}
+	return config, nil
+}
+
+// parseJSONConfig decodes JSON keeping numbers in their original form.
+func parseJSONConfig(data []byte, values *map[string]any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(values)
+}
+
+// lookup returns the value configured for a flag of the command.
+func (f *configFile) lookup(c *Command, flagName string) (any, bool) {
+	if f.dotenv {
+		for _, name := range c.configEnvVars(flagName) {
+			if value, ok := f.values[name]; ok {
+				return value, true
+			}
+		}
+		return nil, false
+	}
+
+	section := f.values
+	if path := strings.Fields(c.commandPath); len(path) > 1 {
+		for _, part := range path[1:] {
+			next, ok := section[part].(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			section = next
+		}
+	}
+	if value, ok := section[flagName]; ok {
+		return value, true
+	}
+	value, ok := section[strings.ReplaceAll(flagName, "-", "_")]
+	return value, ok
+}
+
+// configEnvVars returns the variable names a .env file may use for a flag:
+// the flag's environment bindings and the name derived without a prefix.
+func (c *Command) configEnvVars(flagName string) []string {
+	vars := c.envVars(flagName)
+	return append(vars[:len(vars):len(vars)], c.derivedEnvVar("", flagName))
+}
+
+// applyConfig sets flags that were not given on the command line or through
+// the environment from the loaded configuration files.
+func (c *Command) applyConfig() error {
+	if c.app == nil || len(c.app.configs) == 0 {
+		return nil
+	}
+	var err error
+	c.flags.VisitAll(func(f *flag.Flag) {
+		if err != nil || f.Name == "help" {
+			return
+		}
+		if _, ok := c.flagSources[f.Name]; ok {
+			return
+		}
+		// Later files override earlier ones
+		for i := len(c.app.configs) - 1; i >= 0; i-- {
+			config := c.app.configs[i]
+			value, ok := config.lookup(c, f.Name)
+			if !ok || value == nil {
+				continue
+			}
+			if setErr := c.setConfigValue(f, value); setErr != nil {
+				err = c.invalidValue(f, f.Name, configDisplay(value), valueSource{kind: SourceConfig, location: config.path}, setErr)
+				return
+			}
+			c.flagSources[f.Name] = valueSource{kind: SourceConfig, location: config.path}
+			return
+		}
+	})
+	return err
+}
+
+// setConfigValue sets a flag from a decoded configuration value.
+func (c *Command) setConfigValue(f *flag.Flag, value any) error {
+	list, ok := value.([]any)
+	if !ok {
+		s, err := configString(value)
+		if err != nil {
+			return err
+		}
+		return c.setFlagValues(f, s)
+	}
+	if !isSliceFlag(f.Value) {
+		return fmt.Errorf("expected a single value, got a list")
+	}
+	for _, item := range list {
+		s, err := configString(item)
+		if err != nil {
+			return err
+		}
+		if err := c.flags.Set(f.Name, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configString converts a scalar configuration value to its string form.
+func configString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case nil:
+		return "", nil
+	}
+	return "", fmt.Errorf("expected a value, got a section")
+}
+
+// configDisplay formats a decoded configuration value for error messages.
+func configDisplay(value any) string {
+	if list, ok := value.([]any); ok {
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = configDisplay(item)
+		}
+		return strings.Join(items, ",")
+	}
+	if s, err := configString(value); err == nil {
+		return s
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,319 @@
+package cliz
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zkep/cliz/validator"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return path
+}
+
+func newConfigTestCli(port *int, hosts *[]string, workers *int) *Cli {
+	cli := NewCli("myapp", "test description", "1.0.0")
+	cli.Int("port", "set port", port, Range(1, 65535))
+	start := cli.NewSubCommand("server", "server commands").NewSubCommand("start", "start server")
+	start.StringSlice("hosts", "set hosts", hosts)
+	start.Int("workers", "set workers", workers)
+	start.Action(func() error {
+		return nil
+	})
+	return cli
+}
+
+func TestConfigFileFormats(t *testing.T) {
+	files := map[string]string{
+		"config.json": `{"port": 8080, "server": {"start": {"hosts": ["a", "b"], "workers": 4}}}`,
+		"config.yaml": "port: 8080 # comment\nserver:\n  start:\n    hosts:\n    - a\n    - 'b'\n    workers: 4\n",
+		"config.toml": "port = 8080\n\n[server.start]\nhosts = [\"a\", 'b']\nworkers = 4\n",
+		".env":        "PORT=8080\nexport SERVER_START_HOSTS=\"a,b\"\nSERVER_START_WORKERS=4 # comment\n",
+	}
+	for name, content := range files {
+		var port, workers int
+		var hosts []string
+		cli := newConfigTestCli(&port, &hosts, &workers)
+		cli.ConfigFile(writeConfigFile(t, name, content))
+
+		err := cli.Run([]string{}...)
+		if err != nil {
+			t.Fatalf("%s: Unexpected error: %v", name, err)
+		}
+		if port != 8080 {
+			t.Fatalf("%s: Expected port 8080, got %d", name, port)
+		}
+
+		err = cli.Run("server", "start")
+		if err != nil {
+			t.Fatalf("%s: Unexpected error: %v", name, err)
+		}
+		if len(hosts) != 2 || hosts[0] != "a" || hosts[1] != "b" {
+			t.Fatalf("%s: Expected hosts [a b], got %v", name, hosts)
+		}
+		if workers != 4 {
+			t.Fatalf("%s: Expected workers 4, got %d", name, workers)
+		}
+	}
+}
+
+func TestConfigFilePrecedence(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "port: 1000\nserver:\n  start:\n    workers: 2\n")
+	var port, workers int
+	var hosts []string
+	cli := newConfigTestCli(&port, &hosts, &workers)
+	cli.ConfigFile(path)
+	start := cli.RootCommand().SubCommands()[0].SubCommands()[0]
+
+	t.Setenv("MYAPP_SERVER_START_WORKERS", "3")
+	cli.EnvPrefix("MYAPP")
+	err := cli.Run("server", "start")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if workers != 3 {
+		t.Fatalf("Expected workers 3 from env, got %d", workers)
+	}
+	if origin := start.ValueOrigin("workers"); origin != "environment variable MYAPP_SERVER_START_WORKERS" {
+		t.Fatalf("Unexpected origin '%s'", origin)
+	}
+
+	err = cli.Run("server", "start", "--workers=5")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if workers != 5 {
+		t.Fatalf("Expected workers 5 from argv, got %d", workers)
+	}
+	if origin := start.ValueOrigin("workers"); origin != "command line" {
+		t.Fatalf("Unexpected origin '%s'", origin)
+	}
+
+	err = cli.Run([]string{}...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if port != 1000 {
+		t.Fatalf("Expected port 1000 from config, got %d", port)
+	}
+	if origin := cli.RootCommand().ValueOrigin("port"); origin != "config file "+path {
+		t.Fatalf("Unexpected origin '%s'", origin)
+	}
+	if origin := cli.RootCommand().ValueOrigin("help"); origin != "default" {
+		t.Fatalf("Unexpected origin '%s'", origin)
+	}
+}
+
+func TestConfigFileLayering(t *testing.T) {
+	first := writeConfigFile(t, "first.toml", "port = 1000\n")
+	second := writeConfigFile(t, "second.json", `{"port": 2000}`)
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+	var port, workers int
+	var hosts []string
+	cli := newConfigTestCli(&port, &hosts, &workers)
+	cli.ConfigFile(first, missing, second)
+	err := cli.Run([]string{}...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if port != 2000 {
+		t.Fatalf("Expected port 2000, got %d", port)
+	}
+}
+
+func TestConfigFlag(t *testing.T) {
+	path := writeConfigFile(t, "custom.yaml", "server:\n  start:\n    workers: 7\n")
+	var port, workers int
+	var hosts []string
+	cli := newConfigTestCli(&port, &hosts, &workers)
+	cli.ConfigFlag("config")
+	err := cli.Run("server", "start", "--config", path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if workers != 7 {
+		t.Fatalf("Expected workers 7, got %d", workers)
+	}
+
+	workers = 0
+	err = cli.Run("--config", path, "server", "start")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if workers != 7 {
+		t.Fatalf("Expected workers 7 with --config before the subcommand, got %d", workers)
+	}
+
+	err = cli.Run("server", "start", "--config="+filepath.Join(t.TempDir(), "missing.yaml"))
+	if err == nil {
+		t.Fatalf("Expected error for missing config file, got nil")
+	}
+
+	err = cli.Run("server", "start", "--config")
+	var missingErr *MissingValueError
+	if !errors.As(err, &missingErr) || missingErr.Command != "myapp server start" {
+		t.Fatalf("Expected MissingValueError for server start, got %v", err)
+	}
+}
+
+func TestConfigFlagConflict(t *testing.T) {
+	expectPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected panic for %s", name)
+			}
+		}()
+		fn()
+	}
+	var config string
+	expectPanic("existing flag", func() {
+		cli := NewCli("myapp", "", "")
+		cli.NewSubCommand("server", "").String("config", "config name", &config)
+		cli.ConfigFlag("config")
+	})
+	expectPanic("flag added later", func() {
+		cli := NewCli("myapp", "", "").ConfigFlag("config")
+		cli.String("config", "config name", &config)
+	})
+	expectPanic("short flag", func() {
+		cli := NewCli("myapp", "", "").ConfigFlag("c")
+		cli.String("cluster", "cluster name", &config).Alias("cluster", "c")
+	})
+	expectPanic("added command", func() {
+		cmd := NewCommand("server", "")
+		cmd.String("config", "config name", &config)
+		NewCli("myapp", "", "").ConfigFlag("config").AddCommand(cmd)
+	})
+}
+
+func TestConfigFileValidationError(t *testing.T) {
+	path := writeConfigFile(t, "config.json", `{"port": 70000}`)
+	var port, workers int
+	var hosts []string
+	cli := newConfigTestCli(&port, &hosts, &workers)
+	cli.ConfigFile(path)
+	err := cli.Run([]string{}...)
+	if err == nil {
+		t.Fatalf("Expected validation error, got nil")
+	}
+	var validatorErr *validator.ValidatorError
+	if !errors.As(err, &validatorErr) {
+		t.Fatalf("Expected ValidatorError, got %T", err)
+	}
+	if !strings.Contains(err.Error(), "config file "+path) {
+		t.Fatalf("Expected error to name the config file, got '%v'", err)
+	}
+}
+
+func TestConfigFileInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"bad.json": `{"port": `,
+		"bad.yaml": "port: 1\n  nested: 2\n",
+		"bad.toml": "port 1\n",
+		"bad.env":  "PORT\n",
+		"bad.ini":  "port=1\n",
+	} {
+		var port, workers int
+		var hosts []string
+		cli := newConfigTestCli(&port, &hosts, &workers)
+		cli.ConfigFile(writeConfigFile(t, name, content))
+		if err := cli.Run([]string{}...); err == nil {
+			t.Fatalf("%s: Expected error, got nil", name)
+		}
+	}
+}
+
+func TestConfigFileListForScalarFlag(t *testing.T) {
+	var port, workers int
+	var hosts []string
+	cli := newConfigTestCli(&port, &hosts, &workers)
+	cli.ConfigFile(writeConfigFile(t, "config.yaml", "port: [1, 2]\n"))
+	if err := cli.Run([]string{}...); err == nil {
+		t.Fatalf("Expected error, got nil")
+	}
+}
+
+func TestParseYAMLConfig(t *testing.T) {
+	values, err := parseYAMLConfig("a: \"x: y\"\nb:\n  c: [1, 'two']\n  d: ~\nlist:\n  - 1\n  - 2\n")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if values["a"] != "x: y" {
+		t.Fatalf("Expected a 'x: y', got %v", values["a"])
+	}
+	b := values["b"].(map[string]any)
+	if c := b["c"].([]any); len(c) != 2 || c[0] != "1" || c[1] != "two" {
+		t.Fatalf("Expected c [1 two], got %v", b["c"])
+	}
+	if b["d"] != nil {
+		t.Fatalf("Expected d nil, got %v", b["d"])
+	}
+	if list := values["list"].([]any); len(list) != 2 {
+		t.Fatalf("Expected list of 2, got %v", values["list"])
+	}
+}
+
+func TestParseTOMLConfig(t *testing.T) {
+	values, err := parseTOMLConfig("name = \"a # b\" # comment\nserver.port = 80\n[\"x.y\"]\nz = true\n")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if values["name"] != "a # b" {
+		t.Fatalf("Expected name 'a # b', got %v", values["name"])
+	}
+	if port := values["server"].(map[string]any)["port"]; port != "80" {
+		t.Fatalf("Expected server.port 80, got %v", port)
+	}
+	if z := values["x.y"].(map[string]any)["z"]; z != "true" {
+		t.Fatalf("Expected x.y.z true, got %v", z)
+	}
+}
+
+func TestConfigUnsupportedSyntax(t *testing.T) {
+	tests := []struct {
+		name    string
+		parse   func(string) (map[string]any, error)
+		content string
+		want    string
+	}{
+		{"yaml literal block", parseYAMLConfig, "text: |\n  line\n", "multi-line strings are not supported"},
+		{"yaml folded block", parseYAMLConfig, "text: >-\n  line\n", "multi-line strings are not supported"},
+		{"yaml flow mapping", parseYAMLConfig, "server: {port: 80}\n", "flow mappings are not supported"},
+		{"yaml nested list", parseYAMLConfig, "hosts:\n  - - a\n", "nested values in lists are not supported"},
+		{"yaml mapping in list", parseYAMLConfig, "hosts:\n  - name: a\n", "nested values in lists are not supported"},
+		{"yaml nested flow list", parseYAMLConfig, "hosts: [a, [b]]\n", "nested values in lists are not supported"},
+		{"yaml anchor", parseYAMLConfig, "port: &p 80\n", "anchors, aliases and tags are not supported"},
+		{"yaml alias", parseYAMLConfig, "port: *p\n", "anchors, aliases and tags are not supported"},
+		{"yaml tag", parseYAMLConfig, "port: !!str 80\n", "anchors, aliases and tags are not supported"},
+		{"yaml documents", parseYAMLConfig, "port: 80\n---\nport: 81\n", "multiple documents are not supported"},
+		{"toml multi-line string", parseTOMLConfig, "text = \"\"\"\nline\n\"\"\"\n", "multi-line strings are not supported"},
+		{"toml inline table", parseTOMLConfig, "server = { port = 80 }\n", "inline tables are not supported"},
+		{"toml multi-line array", parseTOMLConfig, "hosts = [\n  \"a\",\n]\n", "multi-line arrays are not supported"},
+		{"toml nested array", parseTOMLConfig, "hosts = [[\"a\"]]\n", "nested arrays are not supported"},
+		{"toml array of tables", parseTOMLConfig, "[[servers]]\n", "arrays of tables are not supported"},
+		{"toml invalid key", parseTOMLConfig, "my key = 1\n", "invalid key"},
+	}
+	for _, tt := range tests {
+		_, err := tt.parse(tt.content)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Expected error containing '%s', got %v", tt.name, tt.want, err)
+		}
+	}
+
+	values, err := parseTOMLConfig("\"a=b\" = \"c=d\"\n")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if values["a=b"] != "c=d" {
+		t.Fatalf("Expected quoted key 'a=b' to be 'c=d', got %v", values)
+	}
+}
@@ -0,0 +1,435 @@
+package cliz
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The configuration readers below support the subset of YAML and TOML that is
+// needed to describe flag values: nested sections, scalars and lists of scalars.
+// Values are kept as strings and converted by the flag parsers, so typing
+// rules of the formats do not matter.
+//
+// YAML: block mappings, block sequences and single line flow sequences of
+// scalars, plain, single and double quoted scalars, comments and a single
+// document. Multi-line strings (| and >), flow mappings, nested sequences,
+// mappings inside sequences, anchors, aliases, tags and multiple documents
+// are rejected with an error.
+//
+// TOML: [tables], bare, quoted and dotted keys, basic and literal strings,
+// single line arrays of scalars and bare values such as numbers, booleans
+// and dates. Multi-line strings, multi-line and nested arrays, inline tables
+// and arrays of tables are rejected with an error.
+
+// yamlLine is a non-empty line of a YAML document.
+type yamlLine struct {
+	indent int
+	text   string
+	number int
+}
+
+// parseYAMLConfig parses block mappings, block and flow sequences of scalars,
+// and plain, single or double quoted scalars.
+func parseYAMLConfig(data string) (map[string]any, error) {
+	var lines []yamlLine
+	for i, raw := range strings.Split(data, "\n") {
+		raw = strings.TrimRight(raw, " \t\r")
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+		text = strings.TrimSpace(stripComment(text))
+		if text == "" {
+			continue
+		}
+		if text == "---" || text == "..." {
+			if len(lines) > 0 {
+				return nil, fmt.Errorf("line %d: multiple documents are not supported", i+1)
+			}
+			continue
+		}
+		lines = append(lines, yamlLine{indent: len(raw) - len(strings.TrimLeft(raw, " ")), text: text, number: i + 1})
+	}
+	if len(lines) == 0 {
+		return map[string]any{}, nil
+	}
+
+	value, next, err := parseYAMLBlock(lines, 0, lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if next < len(lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", lines[next].number)
+	}
+	values, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("line %d: expected a mapping at the top level", lines[0].number)
+	}
+	return values, nil
+}
+
+// parseYAMLBlock parses the mapping or sequence starting at lines[i] with the
+// given indentation. It returns the value and the index of the next line.
+func parseYAMLBlock(lines []yamlLine, i, indent int) (any, int, error) {
+	if isYAMLListItem(lines[i].text) {
+		var list []any
+		for i < len(lines) && lines[i].indent == indent && isYAMLListItem(lines[i].text) {
+			text := strings.TrimSpace(lines[i].text[1:])
+			if _, _, ok := cutYAMLKey(text); ok || isYAMLListItem(text) {
+				return nil, i, fmt.Errorf("line %d: nested values in lists are not supported", lines[i].number)
+			}
+			item, err := yamlScalar(text)
+			if err != nil {
+				return nil, i, fmt.Errorf("line %d: %v", lines[i].number, err)
+			}
+			list = append(list, item)
+			i++
+			if i < len(lines) && lines[i].indent > indent {
+				return nil, i, fmt.Errorf("line %d: nested values in lists are not supported", lines[i].number)
+			}
+		}
+		return list, i, nil
+	}
+
+	values := make(map[string]any)
+	for i < len(lines) && lines[i].indent == indent {
+		line := lines[i]
+		key, value, ok := cutYAMLKey(line.text)
+		if !ok {
+			return nil, i, fmt.Errorf("line %d: expected 'key: value'", line.number)
+		}
+		i++
+		switch {
+		case value != "":
+			v, err := yamlValue(value)
+			if err != nil {
+				return nil, i, fmt.Errorf("line %d: %v", line.number, err)
+			}
+			values[key] = v
+		case i < len(lines) && lines[i].indent > indent:
+			child, next, err := parseYAMLBlock(lines, i, lines[i].indent)
+			if err != nil {
+				return nil, next, err
+			}
+			values[key] = child
+			i = next
+		case i < len(lines) && lines[i].indent == indent && isYAMLListItem(lines[i].text):
+			// Sequences may be indented at the same level as their key
+			child, next, err := parseYAMLBlock(lines, i, indent)
+			if err != nil {
+				return nil, next, err
+			}
+			values[key] = child
+			i = next
+		default:
+			values[key] = nil
+		}
+	}
+	return values, i, nil
+}
+
+// isYAMLListItem reports whether the line is a block sequence entry.
+func isYAMLListItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// cutYAMLKey splits a "key: value" line at the first colon that is followed
+// by a space or ends the line, ignoring colons inside quotes.
+func cutYAMLKey(text string) (string, string, bool) {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == ':' && (i+1 == len(text) || text[i+1] == ' '):
+			key, err := yamlScalar(strings.TrimSpace(text[:i]))
+			if err != nil || key == nil {
+				return "", "", false
+			}
+			return key.(string), strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// yamlValue parses an inline value, which may be a flow sequence.
+func yamlValue(text string) (any, error) {
+	if strings.HasPrefix(text, "{") {
+		return nil, fmt.Errorf("flow mappings are not supported")
+	}
+	if !strings.HasPrefix(text, "[") {
+		return yamlScalar(text)
+	}
+	if !strings.HasSuffix(text, "]") {
+		return nil, fmt.Errorf("unterminated list")
+	}
+	list := []any{}
+	inner := strings.TrimSpace(text[1 : len(text)-1])
+	if inner == "" {
+		return list, nil
+	}
+	for _, item := range splitOutsideQuotes(inner, ',') {
+		v, err := yamlScalar(strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+// yamlScalar parses a plain or quoted scalar. Null values are returned as nil.
+// Plain scalars starting with an indicator of an unsupported feature are rejected.
+func yamlScalar(text string) (any, error) {
+	switch {
+	case text == "" || text == "~" || text == "null":
+		return nil, nil
+	case strings.HasPrefix(text, "|") || strings.HasPrefix(text, ">"):
+		return nil, fmt.Errorf("multi-line strings are not supported")
+	case strings.HasPrefix(text, "&") || strings.HasPrefix(text, "*") || strings.HasPrefix(text, "!"):
+		return nil, fmt.Errorf("anchors, aliases and tags are not supported")
+	case strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{"):
+		return nil, fmt.Errorf("nested values in lists are not supported")
+	case strings.HasPrefix(text, "@") || strings.HasPrefix(text, "`"):
+		return nil, fmt.Errorf("plain values cannot start with %c", text[0])
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("unterminated string %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	case strings.HasPrefix(text, `"`):
+		return strconv.Unquote(text)
+	}
+	return text, nil
+}
+
+// parseTOMLConfig parses tables, dotted keys, basic and literal strings,
+// single line arrays and bare values such as numbers and booleans.
+func parseTOMLConfig(data string) (map[string]any, error) {
+	root := make(map[string]any)
+	current := root
+	for i, raw := range strings.Split(data, "\n") {
+		line := strings.TrimSpace(stripComment(raw))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[[") {
+			return nil, fmt.Errorf("line %d: arrays of tables are not supported", i+1)
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated table header", i+1)
+			}
+			keys, err := splitTOMLKey(line[1 : len(line)-1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			table, err := tomlTable(root, keys)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			current = table
+			continue
+		}
+
+		parts := splitOutsideQuotes(line, '=')
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("line %d: expected 'key = value'", i+1)
+		}
+		key, value := parts[0], line[len(parts[0])+1:]
+		keys, err := splitTOMLKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		table, err := tomlTable(current, keys[:len(keys)-1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		v, err := tomlValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		table[keys[len(keys)-1]] = v
+	}
+	return root, nil
+}
+
+// splitTOMLKey splits a dotted key into its parts, removing quotes.
+// Bare key parts may only contain letters, digits, '_' and '-'.
+func splitTOMLKey(key string) ([]string, error) {
+	parts := splitOutsideQuotes(key, '.')
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, `"`) || strings.HasPrefix(part, "'") {
+			unquoted, err := tomlString(part)
+			if err != nil {
+				return nil, err
+			}
+			parts[i] = unquoted
+			continue
+		}
+		if part == "" || strings.TrimFunc(part, isTOMLBareKeyChar) != "" {
+			return nil, fmt.Errorf("invalid key %q", strings.TrimSpace(key))
+		}
+		parts[i] = part
+	}
+	return parts, nil
+}
+
+// isTOMLBareKeyChar reports whether r may appear in a bare key.
+func isTOMLBareKeyChar(r rune) bool {
+	return r == '_' || r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// tomlTable returns the nested table for keys, creating missing tables.
+func tomlTable(table map[string]any, keys []string) (map[string]any, error) {
+	for _, key := range keys {
+		next, ok := table[key]
+		if !ok {
+			child := make(map[string]any)
+			table[key] = child
+			table = child
+			continue
+		}
+		child, ok := next.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("key %s is already defined as a value", key)
+		}
+		table = child
+	}
+	return table, nil
+}
+
+// tomlValue parses a string, an array or a bare value.
+func tomlValue(text string) (any, error) {
+	switch {
+	case text == "":
+		return nil, fmt.Errorf("missing value")
+	case strings.HasPrefix(text, `"""`) || strings.HasPrefix(text, "'''"):
+		return nil, fmt.Errorf("multi-line strings are not supported")
+	case strings.HasPrefix(text, "{"):
+		return nil, fmt.Errorf("inline tables are not supported")
+	case strings.HasPrefix(text, "["):
+		if !strings.HasSuffix(text, "]") {
+			return nil, fmt.Errorf("unterminated array, multi-line arrays are not supported")
+		}
+		list := []any{}
+		inner := strings.TrimSpace(text[1 : len(text)-1])
+		for _, item := range splitOutsideQuotes(inner, ',') {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			if strings.HasPrefix(item, "[") {
+				return nil, fmt.Errorf("nested arrays are not supported")
+			}
+			v, err := tomlValue(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case strings.HasPrefix(text, `"`) || strings.HasPrefix(text, "'"):
+		return tomlString(text)
+	}
+	return text, nil
+}
+
+// tomlString parses a basic (double quoted) or literal (single quoted) string.
+func tomlString(text string) (string, error) {
+	if strings.HasPrefix(text, "'") {
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return "", fmt.Errorf("unterminated string %s", text)
+		}
+		return text[1 : len(text)-1], nil
+	}
+	return strconv.Unquote(text)
+}
+
+// parseDotEnvConfig parses KEY=VALUE lines, with optional export prefixes
+// and quoted values.
+func parseDotEnvConfig(data string) (map[string]any, error) {
+	values := make(map[string]any)
+	for i, raw := range strings.Split(data, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", i+1)
+		}
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(value, `"`):
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			value = unquoted
+		case strings.HasPrefix(value, "'"):
+			if len(value) < 2 || !strings.HasSuffix(value, "'") {
+				return nil, fmt.Errorf("line %d: unterminated string", i+1)
+			}
+			value = value[1 : len(value)-1]
+		default:
+			value = strings.TrimSpace(stripComment(value))
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	return values, nil
+}
+
+// stripComment removes a trailing # comment that is outside quotes and
+// either starts the text or follows whitespace.
+func stripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' && quote == '"' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return text[:i]
+		}
+	}
+	return text
+}
+
+// splitOutsideQuotes splits text on sep, ignoring separators inside quotes.
+func splitOutsideQuotes(text string, sep byte) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' && quote == '"' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == sep:
+			parts = append(parts, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, text[start:])
+}
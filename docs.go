@@ -0,0 +1,151 @@
+package cliz
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// typedValue is implemented by flag values that know their type name.
+type typedValue interface {
+	Type() string
+}
+
+// flagDocs describes the flags of the command, sorted by name, for help
+// output and generated documentation. The config flag is included when enabled.
+func (c *Command) flagDocs() []HelpFlag {
+	var docs []HelpFlag
+	c.flags.VisitAll(func(f *flag.Flag) {
+		doc := HelpFlag{
+			Name:       f.Name,
+			Short:      c.flagShorts[f.Name],
+			Usage:      f.Usage,
+			Rules:      validationRules(c.flagValidations[f.Name]),
+			Required:   hasRequiredValidator(c.flagValidations[f.Name]),
+			Deprecated: c.deprecatedFlags[f.Name],
+			Bool:       isBoolFlag(f.Value),
+		}
+		if f.Name != "help" {
+			doc.Env = c.envVars(f.Name)
+		}
+		if v, ok := f.Value.(typedValue); ok {
+			doc.Type = v.Type()
+		}
+		if !isZeroDefault(f.DefValue) {
+			doc.Default = f.DefValue
+		}
+		docs = append(docs, doc)
+	})
+	if c.app != nil && c.app.configFlag != "" {
+		docs = append(docs, HelpFlag{Name: c.app.configFlag, Usage: "Load configuration from file.", Type: "string"})
+		sort.Slice(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+	}
+	return docs
+}
+
+// isZeroDefault reports whether a flag default is the zero value of its
+// type and not worth documenting.
+func isZeroDefault(value string) bool {
+	switch value {
+	case "", "0", "false", "[]", "0s":
+		return true
+	}
+	return false
+}
+
+// validationRules describes validators in validate tag form, skipping
+// validators that cannot be described.
+func validationRules(validators []Validator) []string {
+	var rules []string
+	for _, v := range validators {
+		if s, ok := v.(fmt.Stringer); ok && s.String() != "" {
+			rules = append(rules, s.String())
+		}
+	}
+	return rules
+}
+
+// argumentDocs describes the positional arguments of the command, in order,
+// for help output and generated documentation.
+func (c *Command) argumentDocs() []HelpArgument {
+	var docs []HelpArgument
+	for _, arg := range c.positionals {
+		doc := HelpArgument{
+			Name:        arg.name,
+			Description: arg.description,
+			Default:     arg.defaultValue,
+			Rules:       validationRules(arg.validators),
+			Required:    hasRequiredValidator(arg.validators),
+			Variadic:    arg.variadic,
+		}
+		doc.Usage = doc.Name
+		if doc.Variadic {
+			doc.Usage += "..."
+		}
+		if !doc.Required {
+			doc.Usage = "[" + doc.Usage + "]"
+		} else {
+			doc.Usage = "<" + doc.Usage + ">"
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// UseLine returns the usage line of the command, e.g. "myapp server [flags] [command]"
+// or "myapp copy [flags] <src> <dst> [extra...]".
+func (c *Command) UseLine() string {
+	line := c.commandPath + " [flags]"
+	for _, arg := range c.argumentDocs() {
+		line += " " + arg.Usage
+	}
+	if len(c.visibleSubCommands()) > 0 {
+		line += " [command]"
+	}
+	return line
+}
+
+// Example sets usage examples for the command, shown in help and generated documentation.
+func (c *Command) Example(example string) *Command {
+	c.examples = example
+	return c
+}
+
+// visibleSubCommands returns the subcommands that are not hidden.
+func (c *Command) visibleSubCommands() []*Command {
+	var commands []*Command
+	for _, cmd := range c.subCommands {
+		if !cmd.hidden {
+			commands = append(commands, cmd)
+		}
+	}
+	return commands
+}
+
+// description returns the long description of the command, falling back to
+// the short description.
+func (c *Command) description() string {
+	if c.longdescription != "" {
+		return c.longdescription
+	}
+	return c.shortdescription
+}
+
+// docName returns the command path joined with dashes, e.g. "myapp-server-start".
+func (c *Command) docName() string {
+	return strings.Join(strings.Fields(c.commandPath), "-")
+}
+
+// walkVisible calls fn for the command and every visible descendant.
+func (c *Command) walkVisible(fn func(*Command) error) error {
+	if err := fn(c); err != nil {
+		return err
+	}
+	for _, cmd := range c.visibleSubCommands() {
+		if err := cmd.walkVisible(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package cliz
+
+import (
+	"flag"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Env binds one or more environment variables to an existing flag.
+// When the flag is not given on the command line, the first variable that is
+// set to a non-empty value is used. Slice flags split the value on commas.
+// It panics if the flag is not defined.
+func (c *Command) Env(flagName string, vars ...string) *Command {
+	if c.flags.Lookup(flagName) == nil {
+		panic("Env: flag '" + flagName + "' is not defined")
+	}
+	c.flagEnvs[flagName] = append(c.flagEnvs[flagName], vars...)
+	return c
+}
+
+// envVars returns the environment variables bound to a flag.
+// Explicit bindings come first, followed by the name derived from the
+// application's env prefix, if one is set.
+func (c *Command) envVars(flagName string) []string {
+	vars := c.flagEnvs[flagName]
+	if c.app != nil && c.app.envPrefix != "" {
+		vars = append(vars[:len(vars):len(vars)], c.derivedEnvVar(c.app.envPrefix, flagName))
+	}
+	return vars
+}
+
+// derivedEnvVar builds the environment variable name for a flag from the
+// prefix, the command path below the root command and the flag name.
+// For example, prefix MYAPP, command "myapp server start" and flag "port"
+// give MYAPP_SERVER_START_PORT.
+func (c *Command) derivedEnvVar(prefix, flagName string) string {
+	var parts []string
+	if prefix != "" {
+		parts = append(parts, prefix)
+	}
+	if path := strings.Fields(c.commandPath); len(path) > 1 {
+		parts = append(parts, path[1:]...)
+	}
+	parts = append(parts, flagName)
+	return envVarName(strings.Join(parts, "_"))
+}
+
+// envVarName upper-cases name and replaces every character that is not a
+// letter or digit with an underscore.
+func envVarName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// applyEnv sets flags that were not given on the command line from their
+// bound environment variables.
+func (c *Command) applyEnv() error {
+	var err error
+	c.flags.VisitAll(func(f *flag.Flag) {
+		if err != nil || f.Name == "help" {
+			return
+		}
+		if _, ok := c.flagSources[f.Name]; ok {
+			return
+		}
+		for _, name := range c.envVars(f.Name) {
+			value, ok := os.LookupEnv(name)
+			if !ok || value == "" {
+				continue
+			}
+			if setErr := c.setFlagValues(f, value); setErr != nil {
+				err = c.invalidValue(f, f.Name, value, valueSource{kind: SourceEnv, location: name}, setErr)
+				return
+			}
+			c.flagSources[f.Name] = valueSource{kind: SourceEnv, location: name}
+			return
+		}
+	})
+	return err
+}
+
+// setFlagValues sets a flag from a single string, splitting it on commas
+// for slice flags.
+func (c *Command) setFlagValues(f *flag.Flag, value string) error {
+	if !isSliceFlag(f.Value) {
+		return c.flags.Set(f.Name, value)
+	}
+	for _, item := range strings.Split(value, ",") {
+		if err := c.flags.Set(f.Name, strings.TrimSpace(item)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSliceFlag reports whether the flag value collects repeated values.
+func isSliceFlag(value flag.Value) bool {
+	getter, ok := value.(flag.Getter)
+	return ok && reflect.ValueOf(getter.Get()).Kind() == reflect.Slice
+}
+
+// EnvPrefix enables environment variable bindings for every flag in the
+// application. Variable names are derived from the prefix, the command path
+// and the flag name, e.g. MYAPP_SERVER_START_PORT for the "port" flag of
+// "myapp server start", or MYAPP_PORT for a root command flag.
+func (c *Cli) EnvPrefix(prefix string) *Cli {
+	c.envPrefix = prefix
+	return c
+}
+
+// Env binds one or more environment variables to a flag of the root command.
+// This is a convenience method that delegates to rootCommand.Env.
+func (c *Cli) Env(flagName string, vars ...string) *Cli {
+	c.rootCommand.Env(flagName, vars...)
+	return c
+}
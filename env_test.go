@@ -0,0 +1,139 @@
+package cliz
+
+import (
+	"testing"
+)
+
+func TestEnvBinding(t *testing.T) {
+	t.Setenv("APP_PORT", "9090")
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var port int
+	cli.Int("port", "set port", &port).Env("port", "APP_PORT")
+	err := cli.Run([]string{}...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if port != 9090 {
+		t.Fatalf("Expected port 9090, got %d", port)
+	}
+}
+
+func TestEnvArgvTakesPrecedence(t *testing.T) {
+	t.Setenv("APP_PORT", "9090")
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var port int
+	cli.Int("port", "set port", &port).Env("port", "APP_PORT")
+	err := cli.Run("--port=8080")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if port != 8080 {
+		t.Fatalf("Expected port 8080, got %d", port)
+	}
+}
+
+func TestEnvOverridesDefault(t *testing.T) {
+	t.Setenv("APP_NAME", "from-env")
+	cli := NewCli("test-app", "test description", "1.0.0")
+	type config struct {
+		Name string `name:"name" description:"set name" default:"guest" env:"APP_NAME"`
+	}
+	var cfg config
+	cli.AddFlags(&cfg)
+	err := cli.Run([]string{}...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Name != "from-env" {
+		t.Fatalf("Expected name 'from-env', got '%s'", cfg.Name)
+	}
+}
+
+func TestEnvMultipleVars(t *testing.T) {
+	t.Setenv("SECOND_NAME", "second")
+	cli := NewCli("test-app", "test description", "1.0.0")
+	type config struct {
+		Name string `name:"name" description:"set name" env:"FIRST_NAME,SECOND_NAME"`
+	}
+	var cfg config
+	cli.AddFlags(&cfg)
+	err := cli.Run([]string{}...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Name != "second" {
+		t.Fatalf("Expected name 'second', got '%s'", cfg.Name)
+	}
+}
+
+func TestEnvSlice(t *testing.T) {
+	t.Setenv("APP_HOSTS", "a, b,c")
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var hosts []string
+	cli.StringSlice("hosts", "set hosts", &hosts).Env("hosts", "APP_HOSTS")
+	err := cli.Run([]string{}...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(hosts) != 3 || hosts[0] != "a" || hosts[1] != "b" || hosts[2] != "c" {
+		t.Fatalf("Expected hosts [a b c], got %v", hosts)
+	}
+}
+
+func TestEnvPrefix(t *testing.T) {
+	t.Setenv("MYAPP_VERBOSE", "true")
+	t.Setenv("MYAPP_SERVER_START_LISTEN_PORT", "7070")
+	cli := NewCli("myapp", "test description", "1.0.0").EnvPrefix("MYAPP")
+	var verbose bool
+	var port int
+	cli.Bool("verbose", "enable verbose mode", &verbose)
+	start := cli.NewSubCommand("server", "server commands").NewSubCommand("start", "start server")
+	start.Int("listen-port", "set port", &port)
+	start.Action(func() error {
+		return nil
+	})
+
+	err := cli.Run("server", "start")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if port != 7070 {
+		t.Fatalf("Expected port 7070, got %d", port)
+	}
+
+	err = cli.Run([]string{}...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !verbose {
+		t.Fatalf("Expected verbose to be true, got false")
+	}
+}
+
+func TestEnvValidation(t *testing.T) {
+	t.Setenv("APP_PORT", "70000")
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var port int
+	cli.Int("port", "set port", &port, Range(1, 65535)).Env("port", "APP_PORT")
+	err := cli.Run([]string{}...)
+	if err == nil {
+		t.Fatalf("Expected validation error, got nil")
+	}
+}
+
+func TestEnvInvalidValue(t *testing.T) {
+	t.Setenv("APP_PORT", "not-a-number")
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var port int
+	cli.Int("port", "set port", &port).Env("port", "APP_PORT")
+	err := cli.Run([]string{}...)
+	if err == nil {
+		t.Fatalf("Expected error, got nil")
+	}
+}
+
+func TestEnvVarName(t *testing.T) {
+	if got := envVarName("myapp_server_listen-port"); got != "MYAPP_SERVER_LISTEN_PORT" {
+		t.Fatalf("Expected 'MYAPP_SERVER_LISTEN_PORT', got '%s'", got)
+	}
+}
@@ -0,0 +1,191 @@
+package cliz
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// UnknownCommandError is returned when an argument does not name a
+// subcommand of a command that requires one.
+type UnknownCommandError struct {
+	Command     string   // Path of the command that was being resolved
+	Name        string   // The unknown subcommand name
+	Suggestions []string // Similar subcommand names or aliases, closest first
+}
+
+func (e *UnknownCommandError) Error() string {
+	msg := fmt.Sprintf("unknown command %q for %q", e.Name, e.Command)
+	return msg + didYouMean(e.Suggestions, "")
+}
+
+// ExitCode returns ExitCodeUsage.
+func (e *UnknownCommandError) ExitCode() int {
+	return ExitCodeUsage
+}
+
+// AmbiguousCommandError is returned when prefix matching is enabled and an
+// argument is a prefix of more than one subcommand.
+type AmbiguousCommandError struct {
+	Command    string   // Path of the command that was being resolved
+	Name       string   // The ambiguous prefix
+	Candidates []string // Names of the matching subcommands, sorted
+}
+
+func (e *AmbiguousCommandError) Error() string {
+	return fmt.Sprintf("ambiguous command %q for %q, could be: %s", e.Name, e.Command, strings.Join(e.Candidates, ", "))
+}
+
+// ExitCode returns ExitCodeUsage.
+func (e *AmbiguousCommandError) ExitCode() int {
+	return ExitCodeUsage
+}
+
+// UnknownFlagError is returned when a flag that is not defined is given on
+// the command line.
+type UnknownFlagError struct {
+	Command     string   // Path of the command being parsed
+	Flag        string   // The unknown flag name, without dashes
+	Suggestions []string // Similar flag names, without dashes, closest first
+}
+
+func (e *UnknownFlagError) Error() string {
+	msg := "flag provided but not defined: -" + e.Flag
+	return msg + didYouMean(e.Suggestions, "--")
+}
+
+// ExitCode returns ExitCodeUsage.
+func (e *UnknownFlagError) ExitCode() int {
+	return ExitCodeUsage
+}
+
+// MissingValueError is returned when a flag that takes a value is the last
+// argument on the command line.
+type MissingValueError struct {
+	Command string // Path of the command being parsed
+	Flag    string // The flag name as given, without dashes
+}
+
+func (e *MissingValueError) Error() string {
+	return "flag needs an argument: -" + e.Flag
+}
+
+// ExitCode returns ExitCodeUsage.
+func (e *MissingValueError) ExitCode() int {
+	return ExitCodeUsage
+}
+
+// InvalidValueError is returned when a value cannot be converted to the type
+// of its flag, whether it was given on the command line, in an environment
+// variable or in a configuration file, or to the type of a positional argument.
+type InvalidValueError struct {
+	Command  string     // Path of the command being parsed
+	Flag     string     // The flag name as given, without dashes; empty for positional arguments
+	Position int        // Index of the positional argument, when Flag is empty
+	Field    string     // Struct field of the positional argument, when Flag is empty
+	Arg      string     // Name of the positional argument, when Flag is empty
+	Value    string     // The value that could not be converted
+	Type     string     // The expected type, e.g. "int"
+	Source   FlagSource // Where the value was read from
+	Location string     // Environment variable name or config file path
+	Err      error      // Error returned by the parser
+}
+
+func (e *InvalidValueError) Error() string {
+	if e.Flag == "" {
+		return fmt.Sprintf("invalid value %q for argument %s at position %d: %v", e.Value, e.Arg, e.Position, e.Err)
+	}
+	msg := fmt.Sprintf("invalid value %q for flag -%s", e.Value, e.Flag)
+	if e.Source == SourceEnv || e.Source == SourceConfig {
+		msg += " from " + valueSource{kind: e.Source, location: e.Location}.String()
+	}
+	return fmt.Sprintf("%s: %v", msg, e.Err)
+}
+
+func (e *InvalidValueError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns ExitCodeUsage.
+func (e *InvalidValueError) ExitCode() int {
+	return ExitCodeUsage
+}
+
+// ValidationError is the failure of a validator of a single flag or
+// positional argument.
+type ValidationError struct {
+	Flag     string     // Flag name; empty for positional arguments
+	Arg      string     // Name of the positional argument, when Flag is empty
+	Source   FlagSource // Where the invalid value was read from
+	Location string     // Environment variable name or config file path
+	Err      error      // Error returned by the validator, usually a *validator.ValidatorError
+}
+
+func (e *ValidationError) Error() string {
+	if e.Source == SourceEnv || e.Source == SourceConfig {
+		return fmt.Sprintf("%v (from %s)", e.Err, valueSource{kind: e.Source, location: e.Location})
+	}
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors lists the validation failures of a parse: one entry per
+// flag sorted by flag name, then one per violated flag group, then one per
+// positional argument.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ExitCode returns ExitCodeValidation.
+func (e ValidationErrors) ExitCode() int {
+	return ExitCodeValidation
+}
+
+// didYouMean formats suggestions as a hint appended to an error message.
+func didYouMean(suggestions []string, prefix string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		quoted[i] = prefix + s
+	}
+	return ", did you mean " + strings.Join(quoted, " or ") + "?"
+}
+
+// unknownFlag builds an UnknownFlagError for a flag that is not defined on c.
+func (c *Command) unknownFlag(name string) *UnknownFlagError {
+	var names []string
+	if len(name) > 1 {
+		c.flags.VisitAll(func(f *flag.Flag) {
+			names = append(names, f.Name)
+		})
+	}
+	return &UnknownFlagError{Command: c.commandPath, Flag: name, Suggestions: suggest(name, names)}
+}
+
+// handleError passes err to the application's error handler, if one is set.
+// It is called for every parse, validation and dispatch failure.
+func (c *Command) handleError(err error) error {
+	if c.app != nil && c.app.errorHandler != nil {
+		return c.app.errorHandler(c.commandPath, err)
+	}
+	return err
+}
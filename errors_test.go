@@ -0,0 +1,227 @@
+package cliz
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zkep/cliz/validator"
+)
+
+func TestUnknownFlagError(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var verbose bool
+	cli.Bool("verbose", "enable verbose", &verbose)
+	err := cli.Run("--verbos")
+	var flagErr *UnknownFlagError
+	if !errors.As(err, &flagErr) {
+		t.Fatalf("Expected UnknownFlagError, got %v", err)
+	}
+	if flagErr.Flag != "verbos" || len(flagErr.Suggestions) != 1 || flagErr.Suggestions[0] != "verbose" {
+		t.Fatalf("Expected suggestion 'verbose' for 'verbos', got %+v", flagErr)
+	}
+	expected := "flag provided but not defined: -verbos, did you mean --verbose?"
+	if err.Error() != expected {
+		t.Fatalf("Expected error '%s', got '%s'", expected, err.Error())
+	}
+
+	err = cli.Run("-verbos")
+	if !errors.As(err, &flagErr) || flagErr.Flag != "verbos" {
+		t.Fatalf("Expected UnknownFlagError for 'verbos', got %v", err)
+	}
+}
+
+func TestUnknownCommandError(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	cli.NewSubCommand("server", "run the server").Aliases("srv")
+	cli.NewSubCommand("deploy", "deploy the app")
+
+	err := cli.Run("serer")
+	if err != nil {
+		t.Fatalf("Expected unknown commands to be allowed by default, got %v", err)
+	}
+
+	cli.StrictSubcommands(true)
+	var handled string
+	cli.SetErrorFunction(func(path string, err error) error {
+		handled = path
+		return err
+	})
+	err = cli.Run("serer")
+	var cmdErr *UnknownCommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("Expected UnknownCommandError, got %v", err)
+	}
+	if cmdErr.Name != "serer" || len(cmdErr.Suggestions) == 0 || cmdErr.Suggestions[0] != "server" {
+		t.Fatalf("Expected suggestion 'server' for 'serer', got %+v", cmdErr)
+	}
+	if handled != "test-app" {
+		t.Fatalf("Expected error handler to be called for 'test-app', got '%s'", handled)
+	}
+	expected := `unknown command "serer" for "test-app", did you mean server?`
+	if err.Error() != expected {
+		t.Fatalf("Expected error '%s', got '%s'", expected, err.Error())
+	}
+}
+
+func TestAmbiguousCommandError(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0").AllowPrefixMatching(true)
+	cli.NewSubCommand("server", "run the server")
+	cli.NewSubCommand("status", "show status")
+
+	err := cli.Run("s")
+	var cmdErr *AmbiguousCommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("Expected AmbiguousCommandError, got %v", err)
+	}
+	if cmdErr.Command != "test-app" || cmdErr.Name != "s" || strings.Join(cmdErr.Candidates, " ") != "server status" {
+		t.Fatalf("Unexpected error fields: %+v", cmdErr)
+	}
+	expected := `ambiguous command "s" for "test-app", could be: server, status`
+	if err.Error() != expected {
+		t.Fatalf("Expected error '%s', got '%s'", expected, err.Error())
+	}
+	if code := ExitCodeOf(err); code != ExitCodeUsage {
+		t.Fatalf("Expected exit code %d, got %d", ExitCodeUsage, code)
+	}
+}
+
+func TestMissingValueError(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var name string
+	cli.String("name", "your name", &name).Alias("name", "n")
+
+	for _, args := range [][]string{{"--name"}, {"-n"}} {
+		err := cli.Run(args...)
+		var missingErr *MissingValueError
+		if !errors.As(err, &missingErr) {
+			t.Fatalf("Expected MissingValueError for %v, got %v", args, err)
+		}
+		if missingErr.Command != "test-app" || missingErr.Flag != args[0][len(args[0])-len(missingErr.Flag):] {
+			t.Fatalf("Unexpected error fields %+v", missingErr)
+		}
+	}
+}
+
+func TestInvalidValueError(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var port int
+	cli.Int("port", "server port", &port).Env("port", "TEST_APP_PORT")
+
+	err := cli.Run("--port", "abc")
+	var valueErr *InvalidValueError
+	if !errors.As(err, &valueErr) {
+		t.Fatalf("Expected InvalidValueError, got %v", err)
+	}
+	if valueErr.Flag != "port" || valueErr.Value != "abc" || valueErr.Type != "int" || valueErr.Source != SourceArgv {
+		t.Fatalf("Unexpected error fields %+v", valueErr)
+	}
+
+	t.Setenv("TEST_APP_PORT", "xyz")
+	err = cli.Run("--")
+	if !errors.As(err, &valueErr) {
+		t.Fatalf("Expected InvalidValueError, got %v", err)
+	}
+	if valueErr.Source != SourceEnv || valueErr.Location != "TEST_APP_PORT" {
+		t.Fatalf("Unexpected error fields %+v", valueErr)
+	}
+	expected := `invalid value "xyz" for flag -port from environment variable TEST_APP_PORT: ` + valueErr.Err.Error()
+	if err.Error() != expected {
+		t.Fatalf("Expected error '%s', got '%s'", expected, err.Error())
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var port, workers int
+	var name string
+	cli.Int("port", "server port", &port, Range(1, 100))
+	cli.Int("workers", "worker count", &workers, Range(1, 10))
+	cli.String("name", "your name", &name, Required())
+
+	err := cli.Run("--port", "200", "--workers", "20")
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	if len(validationErrs) != 3 {
+		t.Fatalf("Expected 3 validation errors, got %d: %v", len(validationErrs), err)
+	}
+	for i, name := range []string{"name", "port", "workers"} {
+		if validationErrs[i].Flag != name {
+			t.Fatalf("Expected error %d for flag '%s', got '%s'", i, name, validationErrs[i].Flag)
+		}
+	}
+	var validatorErr *validator.ValidatorError
+	if !errors.As(err, &validatorErr) {
+		t.Fatalf("Expected errors.As to find a ValidatorError in %v", err)
+	}
+}
+
+func TestErrorHandlerReceivesAllFailures(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var port int
+	cli.Int("port", "server port", &port, Range(1, 100))
+	cli.ConfigFlag("config")
+	var handled []error
+	cli.SetErrorFunction(func(path string, err error) error {
+		handled = append(handled, err)
+		return err
+	})
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	failures := [][]string{
+		{"--port", "abc"},
+		{"--port"},
+		{"--port", "200"},
+		{"--config"},
+		{"--config", path},
+	}
+	for _, args := range failures {
+		if err := cli.Run(args...); err == nil {
+			t.Fatalf("Expected error for %v", args)
+		}
+	}
+	if len(handled) != len(failures) {
+		t.Fatalf("Expected %d handled errors, got %d: %v", len(failures), len(handled), handled)
+	}
+	var missingErr *MissingValueError
+	if !errors.As(handled[3], &missingErr) || missingErr.Flag != "config" {
+		t.Fatalf("Expected MissingValueError for the config flag, got %v", handled[3])
+	}
+}
+
+func TestDispatchErrorsAreTyped(t *testing.T) {
+	newCli := func() *Cli {
+		cli := NewCli("test-app", "test description", "1.0.0").AllowPrefixMatching(true).StrictSubcommands(true)
+		var port int
+		cli.Int("port", "set port", &port)
+		cli.NewSubCommand("server", "run the server").Action(func() error { return nil })
+		cli.NewSubCommand("status", "show status").Action(func() error { return nil })
+		return cli
+	}
+	tests := []struct {
+		args   []string
+		target any
+	}{
+		{[]string{"deploy"}, new(*UnknownCommandError)},
+		{[]string{"s"}, new(*AmbiguousCommandError)},
+		{[]string{"--verbose"}, new(*UnknownFlagError)},
+		{[]string{"--port"}, new(*MissingValueError)},
+		{[]string{"--port", "x"}, new(*InvalidValueError)},
+	}
+	for _, tt := range tests {
+		err := newCli().Run(tt.args...)
+		if !errors.As(err, tt.target) {
+			t.Fatalf("%v: Expected %T, got %v", tt.args, tt.target, err)
+		}
+		if code := ExitCodeOf(err); code != ExitCodeUsage {
+			t.Fatalf("%v: Expected exit code %d, got %d", tt.args, ExitCodeUsage, code)
+		}
+	}
+}
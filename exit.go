@@ -0,0 +1,106 @@
+package cliz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/zkep/cliz/validator"
+)
+
+// Exit codes returned by ExitCodeOf and used by Execute.
+const (
+	ExitCodeOK          = 0   // The command succeeded
+	ExitCodeError       = 1   // The command failed
+	ExitCodeUsage       = 2   // The command line could not be parsed
+	ExitCodeValidation  = 3   // Flag or argument values failed validation
+	ExitCodeInterrupted = 130 // The run was cancelled, e.g. by SIGINT
+)
+
+// ExitError wraps an error with the exit code Execute exits with.
+type ExitError struct {
+	Err  error
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code of the error.
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+// ExitCode wraps err so that Execute exits with the given code, e.g.
+// return cliz.ExitCode(err, 4) from an action.
+func ExitCode(err error, code int) error {
+	return &ExitError{Err: err, Code: code}
+}
+
+// exitCoder is implemented by errors that know their exit code.
+type exitCoder interface {
+	ExitCode() int
+}
+
+// ExitCodeOf returns the exit code for an error returned by Run:
+// ExitCodeOK for nil, the code of an ExitCode wrapper or any error with an
+// ExitCode method, ExitCodeInterrupted for context cancellation,
+// ExitCodeValidation for validation errors and ExitCodeError otherwise.
+func ExitCodeOf(err error) int {
+	var coder exitCoder
+	var validationErr *validator.ValidatorError
+	var cliValidationErr *ValidatorError
+	switch {
+	case err == nil:
+		return ExitCodeOK
+	case errors.As(err, &coder):
+		return coder.ExitCode()
+	case errors.Is(err, context.Canceled):
+		return ExitCodeInterrupted
+	case errors.As(err, &validationErr), errors.As(err, &cliValidationErr):
+		return ExitCodeValidation
+	}
+	return ExitCodeError
+}
+
+// SetExitFunction sets the function used to exit the process, which defaults
+// to os.Exit. Tests can replace it to observe exit codes.
+func (c *Cli) SetExitFunction(fn func(code int)) {
+	c.exitFunction = fn
+}
+
+// Execute runs the application with os.Args and exits on failure.
+// Errors are printed to the error output prefixed with "Error: ", and the exit
+// code is derived from the error with ExitCodeOf.
+func (c *Cli) Execute() {
+	c.ExecuteContext(context.Background())
+}
+
+// ExecuteContext is like Execute but runs the application with ctx.
+func (c *Cli) ExecuteContext(ctx context.Context) {
+	err := c.RunContext(ctx)
+	if err == nil {
+		return
+	}
+	fmt.Fprintf(c.rootCommand.ErrOutput(), "Error: %v\n", err)
+	c.exitFunction(ExitCodeOf(err))
+}
+
+// exit calls the application's exit function, or os.Exit for commands that
+// do not belong to an application.
+func (c *Command) exit(code int) {
+	if c.app != nil && c.app.exitFunction != nil {
+		c.app.exitFunction(code)
+		return
+	}
+	os.Exit(code)
+}
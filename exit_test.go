@@ -0,0 +1,89 @@
+package cliz
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestExitCodeOf(t *testing.T) {
+	cli := NewCli("myapp", "", "")
+	var port int
+	cli.Int("port", "Server port", &port, Range(1, 10))
+	cli.Action(func() error { return nil })
+
+	if code := ExitCodeOf(nil); code != ExitCodeOK {
+		t.Errorf("Expected %d for nil, got %d", ExitCodeOK, code)
+	}
+	if code := ExitCodeOf(errors.New("failed")); code != ExitCodeError {
+		t.Errorf("Expected %d for plain error, got %d", ExitCodeError, code)
+	}
+	if code := ExitCodeOf(fmt.Errorf("wrapped: %w", ExitCode(errors.New("failed"), 4))); code != 4 {
+		t.Errorf("Expected 4 for ExitCode wrapper, got %d", code)
+	}
+	if code := ExitCodeOf(fmt.Errorf("stopped: %w", context.Canceled)); code != ExitCodeInterrupted {
+		t.Errorf("Expected %d for cancellation, got %d", ExitCodeInterrupted, code)
+	}
+	if code := ExitCodeOf(cli.Run("--verbose")); code != ExitCodeUsage {
+		t.Errorf("Expected %d for unknown flag, got %d", ExitCodeUsage, code)
+	}
+	if code := ExitCodeOf(cli.Run("--port", "abc")); code != ExitCodeUsage {
+		t.Errorf("Expected %d for invalid value, got %d", ExitCodeUsage, code)
+	}
+	if code := ExitCodeOf(cli.Run("--port")); code != ExitCodeUsage {
+		t.Errorf("Expected %d for missing value, got %d", ExitCodeUsage, code)
+	}
+	if code := ExitCodeOf(cli.Run("--port", "20")); code != ExitCodeValidation {
+		t.Errorf("Expected %d for validation error, got %d", ExitCodeValidation, code)
+	}
+}
+
+func TestExecute(t *testing.T) {
+	args := os.Args
+	defer func() { os.Args = args }()
+
+	var errOut bytes.Buffer
+	code := -1
+	cli := NewCli("myapp", "", "").SetErrOutput(&errOut)
+	cli.SetExitFunction(func(c int) { code = c })
+	cli.NewSubCommand("fail", "Fail").Action(func() error {
+		return ExitCode(errors.New("boom"), 5)
+	})
+	cli.NewSubCommand("ok", "Succeed").Action(func() error { return nil })
+
+	os.Args = []string{"myapp", "fail"}
+	cli.Execute()
+	if code != 5 {
+		t.Errorf("Expected exit code 5, got %d", code)
+	}
+	if errOut.String() != "Error: boom\n" {
+		t.Errorf("Unexpected error output %q", errOut.String())
+	}
+
+	code = -1
+	os.Args = []string{"myapp", "ok"}
+	cli.Execute()
+	if code != -1 {
+		t.Errorf("Expected no exit on success, got %d", code)
+	}
+}
+
+func TestExitWithError(t *testing.T) {
+	var out, errOut bytes.Buffer
+	var codes []int
+	cli := NewCli("myapp", "", "").SetOutput(&out).SetErrOutput(&errOut)
+	cli.SetExitFunction(func(code int) { codes = append(codes, code) })
+	cmd := cli.RootCommand()
+
+	cmd.ExitWithError(ExitCode(errors.New("bad input"), ExitCodeUsage))
+	cmd.Exit("done")
+	if len(codes) != 2 || codes[0] != ExitCodeUsage || codes[1] != ExitCodeOK {
+		t.Errorf("Unexpected exit codes %v", codes)
+	}
+	if errOut.String() != "bad input\n" || out.String() != "done\n" {
+		t.Errorf("Unexpected output %q and %q", out.String(), errOut.String())
+	}
+}
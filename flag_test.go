@@ -1,7 +1,9 @@
 package cliz
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -648,6 +650,21 @@ func TestUint8(t *testing.T) {
 	}
 }
 
+func TestZeroPaddedFlags(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var count uint8
+	var level int
+	cli.Uint8("count", "set count", &count)
+	cli.Int("level", "set level", &level)
+	err := cli.Run("--count", "010", "--level=08")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 10 || level != 8 {
+		t.Fatalf("Expected 10 and 8, got %d and %d", count, level)
+	}
+}
+
 func TestUint16(t *testing.T) {
 	cli := NewCli("test-app", "test description", "1.0.0")
 	var flagValue uint16
@@ -1233,7 +1250,7 @@ func TestValidateTagsWithContains(t *testing.T) {
 func TestValidateTagsWithIn(t *testing.T) {
 	cli := NewCli("test-app", "test description", "1.0.0")
 	type config struct {
-		Option string `name:"option" description:"option" validate:"in=option1,option2"`
+		Option string `name:"option" description:"option" validate:"in=option1|option2"`
 	}
 	var cfg config
 	cli.AddFlags(&cfg)
@@ -1341,7 +1358,7 @@ func TestAddFlagsWithValidationTags(t *testing.T) {
 	cli := NewCli("test-app", "test description", "1.0.0")
 	type config struct {
 		Email string `name:"email" description:"email address" validate:"email"`
-		Age   int    `name:"age" description:"age" validate:"min=18,max=100"`
+		Age   int    `name:"age" description:"age" validate:"range=18-100"`
 	}
 	var cfg config
 	cli.AddFlags(&cfg)
@@ -1436,3 +1453,118 @@ func TestSetFieldDefaultValueComplexScenario(t *testing.T) {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 }
+
+type crossFieldFlags struct {
+	Mode     string `name:"mode" description:"Run mode" default:"dev"`
+	Cert     string `name:"cert" description:"Certificate file" validate:"required_if=Mode prod"`
+	Key      string `name:"key" description:"Key file" validate:"required_with=Cert"`
+	Token    string `name:"token" description:"Auth token" validate:"excluded_with=Key"`
+	Password string `name:"password" description:"Password"`
+	Confirm  string `name:"confirm" description:"Password again" validate:"eqfield=Password"`
+	MinPort  int    `name:"min-port" description:"Lowest port" default:"1000"`
+	MaxPort  int    `name:"max-port" description:"Highest port" validate:"gtfield=MinPort"`
+}
+
+func TestAddFlagsCrossField(t *testing.T) {
+	newCli := func() *Cli {
+		cli := NewCli("myapp", "test description", "1.0.0")
+		cli.AddFlags(&crossFieldFlags{})
+		cli.Action(func() error { return nil })
+		return cli
+	}
+
+	if err := newCli().Run("--"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := newCli().Run("--mode", "prod", "--cert", "a.pem", "--key", "a.key", "--max-port", "2000"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err := newCli().Run("--mode", "prod", "--key", "a.key", "--token", "t",
+		"--password", "secret", "--confirm", "secrets", "--max-port", "80")
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	expected := []string{
+		"cert: is required when --mode is prod",
+		"confirm: must equal --password",
+		"max-port: must be greater than --min-port",
+		"token: cannot be used with --key",
+	}
+	if len(validationErrs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %v", len(expected), err)
+	}
+	for i, want := range expected {
+		if validationErrs[i].Error() != want {
+			t.Errorf("Expected error '%s', got '%s'", want, validationErrs[i].Error())
+		}
+	}
+
+	err = newCli().Run("--cert", "a.pem")
+	if err == nil || err.Error() != "key: is required when --cert is set" {
+		t.Fatalf("Expected required_with error, got %v", err)
+	}
+}
+
+func TestAddFlagsCrossFieldUnknownField(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Expected panic for unknown field")
+		}
+	}()
+	cli := NewCli("myapp", "test description", "1.0.0")
+	cli.AddFlags(&struct {
+		Cert string `name:"cert" description:"Certificate file" validate:"required_with=Key"`
+	}{})
+}
+
+func TestAddFlagsUnknownValidateTag(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(fmt.Sprint(r), "invalid validate tag 'semver': unknown validator") {
+			t.Fatalf("Expected panic for unknown validate tag, got %v", r)
+		}
+	}()
+	cli := NewCli("myapp", "test description", "1.0.0")
+	cli.AddFlags(&struct {
+		Version string `name:"version" description:"Release version" validate:"semver"`
+	}{})
+}
+
+func TestSliceDefaultIsReplaced(t *testing.T) {
+	type config struct {
+		Tags []string `name:"tags" description:"set tags" default:"a,b" env:"APP_TAGS"`
+	}
+	run := func(configFile string, args ...string) []string {
+		t.Helper()
+		var cfg config
+		cli := NewCli("test-app", "test description", "1.0.0")
+		cli.AddFlags(&cfg)
+		if configFile != "" {
+			cli.ConfigFile(configFile)
+		}
+		if err := cli.Run(append(args, "--")...); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return cfg.Tags
+	}
+
+	if got := run(""); fmt.Sprint(got) != "[a b]" {
+		t.Fatalf("Expected default [a b], got %v", got)
+	}
+	if got := run("", "--tags", "c", "--tags", "d"); fmt.Sprint(got) != "[c d]" {
+		t.Fatalf("Expected argv [c d], got %v", got)
+	}
+	configFile := writeConfigFile(t, "config.yaml", "tags:\n  - x\n  - y\n")
+	if got := run(configFile); fmt.Sprint(got) != "[x y]" {
+		t.Fatalf("Expected config [x y], got %v", got)
+	}
+	t.Setenv("APP_TAGS", "e,f")
+	if got := run(configFile); fmt.Sprint(got) != "[e f]" {
+		t.Fatalf("Expected env [e f], got %v", got)
+	}
+	if got := run(configFile, "--tags", "c"); fmt.Sprint(got) != "[c]" {
+		t.Fatalf("Expected argv [c], got %v", got)
+	}
+}
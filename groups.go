@@ -0,0 +1,127 @@
+package cliz
+
+import (
+	"strings"
+)
+
+// flagGroupKind is the constraint a flag group puts on its flags.
+type flagGroupKind int
+
+const (
+	mutuallyExclusive flagGroupKind = iota
+	requiredTogether
+	oneRequired
+)
+
+// flagGroup is a constraint on a set of flags.
+type flagGroup struct {
+	kind  flagGroupKind
+	names []string
+}
+
+// MutuallyExclusive makes it an error to set more than one of the flags,
+// e.g. cmd.MutuallyExclusive("file", "url").
+// It panics if fewer than two flags are given or a flag is not defined.
+func (c *Command) MutuallyExclusive(flagNames ...string) *Command {
+	return c.addFlagGroup("MutuallyExclusive", mutuallyExclusive, flagNames)
+}
+
+// RequiredTogether makes it an error to set some but not all of the flags,
+// e.g. cmd.RequiredTogether("user", "password").
+// It panics if fewer than two flags are given or a flag is not defined.
+func (c *Command) RequiredTogether(flagNames ...string) *Command {
+	return c.addFlagGroup("RequiredTogether", requiredTogether, flagNames)
+}
+
+// OneRequired makes it an error to set none of the flags,
+// e.g. cmd.OneRequired("id", "name").
+// It panics if fewer than two flags are given or a flag is not defined.
+func (c *Command) OneRequired(flagNames ...string) *Command {
+	return c.addFlagGroup("OneRequired", oneRequired, flagNames)
+}
+
+// addFlagGroup registers a flag group, checking that its flags exist.
+func (c *Command) addFlagGroup(method string, kind flagGroupKind, names []string) *Command {
+	if len(names) < 2 {
+		panic(method + ": at least two flags are required")
+	}
+	for _, name := range names {
+		if c.flags.Lookup(name) == nil {
+			panic(method + ": flag '" + name + "' is not defined")
+		}
+	}
+	c.flagGroups = append(c.flagGroups, flagGroup{kind: kind, names: names})
+	return c
+}
+
+// validateFlagGroups checks the flag groups of the command against the flags
+// that were set. It returns one error per violated group.
+func (c *Command) validateFlagGroups() ValidationErrors {
+	var errs ValidationErrors
+	for _, group := range c.flagGroups {
+		var set, unset []string
+		for _, name := range group.names {
+			if c.IsSet(name) {
+				set = append(set, name)
+			} else {
+				unset = append(unset, name)
+			}
+		}
+
+		var err *ValidatorError
+		switch {
+		case group.kind == mutuallyExclusive && len(set) > 1:
+			err = &ValidatorError{Field: set[1], Message: "cannot be used with --" + set[0]}
+		case group.kind == requiredTogether && len(set) > 0 && len(unset) > 0:
+			err = &ValidatorError{Field: unset[0], Message: "is required when --" + set[0] + " is set"}
+		case group.kind == oneRequired && len(set) == 0:
+			err = &ValidatorError{Field: group.names[0], Message: "one of " + dashedNames(group.names, " or ") + " is required"}
+		}
+		if err != nil {
+			errs = append(errs, &ValidationError{Flag: err.Field, Err: err})
+		}
+	}
+	return errs
+}
+
+// String describes the constraint, e.g. "--file and --url are mutually exclusive".
+func (g flagGroup) String() string {
+	switch g.kind {
+	case mutuallyExclusive:
+		return dashedNames(g.names, " and ") + " are mutually exclusive"
+	case requiredTogether:
+		return dashedNames(g.names, " and ") + " must be used together"
+	}
+	return "one of " + dashedNames(g.names, " or ") + " is required"
+}
+
+// dashedNames formats flag names as "--a, --b and --c" with the given final
+// separator.
+func dashedNames(names []string, last string) string {
+	dashed := make([]string, len(names))
+	for i, name := range names {
+		dashed[i] = "--" + name
+	}
+	if len(dashed) == 1 {
+		return dashed[0]
+	}
+	return strings.Join(dashed[:len(dashed)-1], ", ") + last + dashed[len(dashed)-1]
+}
+
+// MutuallyExclusive makes it an error to set more than one of the root command's flags.
+func (c *Cli) MutuallyExclusive(flagNames ...string) *Cli {
+	c.rootCommand.MutuallyExclusive(flagNames...)
+	return c
+}
+
+// RequiredTogether makes it an error to set some but not all of the root command's flags.
+func (c *Cli) RequiredTogether(flagNames ...string) *Cli {
+	c.rootCommand.RequiredTogether(flagNames...)
+	return c
+}
+
+// OneRequired makes it an error to set none of the root command's flags.
+func (c *Cli) OneRequired(flagNames ...string) *Cli {
+	c.rootCommand.OneRequired(flagNames...)
+	return c
+}
@@ -0,0 +1,84 @@
+package cliz
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newGroupsTestCli() *Cli {
+	cli := NewCli("myapp", "test description", "1.0.0")
+	var file, url, user, password, id, name string
+	cli.String("file", "Input file", &file).
+		String("url", "Input URL", &url).
+		String("user", "User name", &user).
+		String("password", "Password", &password).
+		String("id", "Record ID", &id).
+		String("name", "Record name", &name)
+	cli.MutuallyExclusive("file", "url").
+		RequiredTogether("user", "password").
+		OneRequired("id", "name")
+	cli.Action(func() error { return nil })
+	return cli
+}
+
+func TestFlagGroups(t *testing.T) {
+	cli := newGroupsTestCli()
+
+	if err := cli.Run("--id", "1", "--file", "a.txt", "--user", "bob", "--password", "secret"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cli = newGroupsTestCli()
+	err := cli.Run("--file", "a.txt", "--url", "http://example.com", "--user", "bob")
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	expected := []string{
+		"url: cannot be used with --file",
+		"password: is required when --user is set",
+		"id: one of --id or --name is required",
+	}
+	if len(validationErrs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %v", len(expected), err)
+	}
+	for i, want := range expected {
+		if validationErrs[i].Error() != want {
+			t.Errorf("Expected error '%s', got '%s'", want, validationErrs[i].Error())
+		}
+	}
+	var validatorErr *ValidatorError
+	if !errors.As(err, &validatorErr) || validatorErr.Field != "url" {
+		t.Fatalf("Expected a ValidatorError for url, got %v", err)
+	}
+}
+
+func TestFlagGroupsFromEnv(t *testing.T) {
+	cli := newGroupsTestCli()
+	cli.Env("name", "MYAPP_NAME")
+	t.Setenv("MYAPP_NAME", "record")
+	if err := cli.Run("--"); err != nil {
+		t.Fatalf("Expected flags set from the environment to count, got %v", err)
+	}
+}
+
+func TestFlagGroupsHelp(t *testing.T) {
+	out := captureStdout(t, newGroupsTestCli().PrintHelp)
+	expected := "Flag constraints:\n" +
+		"  --file and --url are mutually exclusive\n" +
+		"  --user and --password must be used together\n" +
+		"  one of --id or --name is required\n"
+	if !strings.Contains(out, expected) {
+		t.Fatalf("Expected help to contain %q, got:\n%s", expected, out)
+	}
+}
+
+func TestFlagGroupsUndefinedFlag(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected panic for undefined flag")
+		}
+	}()
+	newGroupsTestCli().MutuallyExclusive("file", "missing")
+}
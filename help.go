@@ -0,0 +1,263 @@
+package cliz
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/zkep/cliz/validator"
+)
+
+// HelpData is the data model passed to help templates.
+type HelpData struct {
+	Name             string             // Command name
+	Path             string             // Full command path, e.g. "myapp server start"
+	UseLine          string             // Usage line, e.g. "myapp server [flags] [command]"
+	ShortDescription string             // One line description
+	LongDescription  string             // Detailed description, may be empty
+	Aliases          []string           // Alternative names of the command
+	Examples         string             // Usage examples, may be empty
+	Arguments        []HelpArgument     // Positional arguments, in order
+	CommandGroups    []HelpCommandGroup // Visible subcommands, grouped
+	Flags            []HelpFlag         // Flags, sorted by name
+	FlagGroups       []string           // Constraints on combinations of flags, e.g. "--file and --url are mutually exclusive"
+	ArgumentWidth    int                // Width of the widest HelpArgument.Usage
+	CommandWidth     int                // Width of the widest HelpCommand.DisplayName
+	FlagWidth        int                // Width of the widest HelpFlag.Synopsis
+}
+
+// HelpArgument describes a positional argument in help output and generated
+// documentation.
+type HelpArgument struct {
+	Name        string   // Name from the name tag, or the lower-cased field name
+	Usage       string   // Usage form, e.g. "<src>" or "[files...]"
+	Description string   // Description from the description tag
+	Default     string   // Value of the default tag
+	Rules       []string // Validation rules in validate tag form
+	Required    bool     // Whether the argument has a Required validator
+	Variadic    bool     // Whether the argument collects all remaining arguments
+}
+
+// HelpCommandGroup is a titled group of subcommands.
+// Subcommands without a group are listed under "Commands".
+type HelpCommandGroup struct {
+	Title    string
+	Commands []HelpCommand
+}
+
+// HelpCommand describes a subcommand in help output.
+type HelpCommand struct {
+	Name        string   // Command name
+	Aliases     []string // Alternative names
+	DisplayName string   // Name followed by aliases, e.g. "remove (rm, del)"
+	Description string   // One line description
+}
+
+// HelpFlag describes a flag in help output and generated documentation.
+type HelpFlag struct {
+	Name       string   // Long name, without dashes
+	Short      string   // Single character alias, may be empty
+	Usage      string   // Description given when the flag was added
+	Type       string   // Value type, e.g. "int" or "[]string"
+	Default    string   // Default value, empty for zero values
+	Env        []string // Bound environment variables
+	Rules      []string // Validation rules in validate tag form
+	Required   bool     // Whether the flag has a Required validator
+	Deprecated string   // Deprecation message, empty if not deprecated
+	Bool       bool     // Whether the flag can be given without a value
+}
+
+// Synopsis returns the flag names and value type, e.g. "-p, --port int".
+func (f HelpFlag) Synopsis() string {
+	names := "    --" + f.Name
+	if f.Short != "" {
+		names = "-" + f.Short + ", --" + f.Name
+	}
+	if !f.Bool && f.Type != "" {
+		names += " " + f.Type
+	}
+	return names
+}
+
+// Description returns the usage text followed by the deprecation message.
+func (f HelpFlag) Description() string {
+	if f.Deprecated == "" {
+		return f.Usage
+	}
+	return strings.TrimSpace(f.Usage + " (deprecated: " + f.Deprecated + ")")
+}
+
+// DefaultHelpTemplate is the template used when no custom help template is set.
+const DefaultHelpTemplate = `{{.Path}}
+{{with .ShortDescription}}
+{{.}}
+{{end}}{{with .LongDescription}}
+{{.}}
+{{end}}
+Usage:
+  {{.UseLine}}
+{{with .Arguments}}
+Arguments:
+{{range .}}  {{pad .Usage $.ArgumentWidth}}  {{.Description}}{{with .Default}} (default {{.}}){{end}}
+{{end}}{{end}}{{with .Aliases}}
+Aliases:
+  {{join . ", "}}
+{{end}}{{with .Examples}}
+Examples:
+{{indent 2 .}}
+{{end}}{{range .CommandGroups}}
+{{.Title}}:
+{{range .Commands}}  {{pad .DisplayName $.CommandWidth}}  {{.Description}}
+{{end}}{{end}}{{with .Flags}}
+Flags:
+{{range .}}  {{pad .Synopsis $.FlagWidth}}  {{.Description}}{{with .Default}} (default {{.}}){{end}}{{if .Required}} (required){{end}}{{with .Env}} [env: {{join . ", "}}]{{end}}
+{{end}}{{end}}{{with .FlagGroups}}
+Flag constraints:
+{{range .}}  {{.}}
+{{end}}{{end}}
+`
+
+// helpFuncs are the functions available to help templates.
+var helpFuncs = template.FuncMap{
+	"join": strings.Join,
+	"trim": strings.TrimSpace,
+	"pad": func(s string, width int) string {
+		return fmt.Sprintf("%-*s", width, s)
+	},
+	"indent": func(spaces int, s string) string {
+		prefix := strings.Repeat(" ", spaces)
+		lines := strings.Split(strings.Trim(s, "\n"), "\n")
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = prefix + line
+			}
+		}
+		return strings.Join(lines, "\n")
+	},
+}
+
+var defaultHelpTemplate = parseHelpTemplate(DefaultHelpTemplate)
+
+// parseHelpTemplate parses a help template, panicking if it is invalid.
+func parseHelpTemplate(text string) *template.Template {
+	return template.Must(template.New("help").Funcs(helpFuncs).Parse(text))
+}
+
+// SetHelpTemplate sets the text/template used to print help for every command
+// that does not have its own template. The template is executed with a
+// HelpData value and may use the join, trim, pad and indent functions.
+// It panics if the template cannot be parsed.
+func (c *Cli) SetHelpTemplate(text string) *Cli {
+	c.helpTemplate = parseHelpTemplate(text)
+	return c
+}
+
+// SetHelpTemplate sets the template used to print help for this command,
+// overriding the application template. See Cli.SetHelpTemplate.
+func (c *Command) SetHelpTemplate(text string) *Command {
+	c.helpTemplate = parseHelpTemplate(text)
+	return c
+}
+
+// Group sets the title of the group the command is listed under in its
+// parent's help, e.g. "Management Commands". Groups are listed in the order
+// they first appear; commands without a group are listed under "Commands".
+func (c *Command) Group(title string) *Command {
+	c.group = title
+	return c
+}
+
+// DeprecateFlag marks a flag as deprecated. The message is shown in help and
+// a warning is printed when the flag is used on the command line.
+// It panics if the flag is not defined or the message is empty.
+func (c *Command) DeprecateFlag(flagName, message string) *Command {
+	if c.flags.Lookup(flagName) == nil {
+		panic("DeprecateFlag: flag '" + flagName + "' is not defined")
+	}
+	if message == "" {
+		panic("DeprecateFlag: flag '" + flagName + "' needs a deprecation message")
+	}
+	c.deprecatedFlags[flagName] = message
+	return c
+}
+
+// warnDeprecatedFlags prints a warning for every deprecated flag given on the
+// command line.
+func (c *Command) warnDeprecatedFlags() {
+	for name, message := range c.deprecatedFlags {
+		if c.FlagSource(name) == SourceArgv {
+			fmt.Fprintf(c.ErrOutput(), "Flag --%s has been deprecated, %s\n", name, message)
+		}
+	}
+}
+
+// HelpData returns the data passed to the command's help template.
+func (c *Command) HelpData() HelpData {
+	data := HelpData{
+		Name:             c.name,
+		Path:             c.commandPath,
+		UseLine:          c.UseLine(),
+		ShortDescription: c.shortdescription,
+		LongDescription:  strings.TrimSpace(c.longdescription),
+		Aliases:          c.aliases,
+		Examples:         c.examples,
+		Arguments:        c.argumentDocs(),
+		Flags:            c.flagDocs(),
+	}
+	for _, group := range c.flagGroups {
+		data.FlagGroups = append(data.FlagGroups, group.String())
+	}
+
+	groups := make(map[string]int)
+	for _, cmd := range c.visibleSubCommands() {
+		title := cmd.group
+		if title == "" {
+			title = "Commands"
+		}
+		i, ok := groups[title]
+		if !ok {
+			i = len(data.CommandGroups)
+			groups[title] = i
+			data.CommandGroups = append(data.CommandGroups, HelpCommandGroup{Title: title})
+		}
+		command := HelpCommand{
+			Name:        cmd.name,
+			Aliases:     cmd.aliases,
+			DisplayName: cmd.displayName(),
+			Description: cmd.shortdescription,
+		}
+		data.CommandGroups[i].Commands = append(data.CommandGroups[i].Commands, command)
+		data.CommandWidth = max(data.CommandWidth, len(command.DisplayName))
+	}
+	for _, arg := range data.Arguments {
+		data.ArgumentWidth = max(data.ArgumentWidth, len(arg.Usage))
+	}
+	for _, f := range data.Flags {
+		data.FlagWidth = max(data.FlagWidth, len(f.Synopsis()))
+	}
+	return data
+}
+
+// writeHelp renders the command's help template to w.
+func (c *Command) writeHelp(w io.Writer) error {
+	tmpl := defaultHelpTemplate
+	if c.helpTemplate != nil {
+		tmpl = c.helpTemplate
+	} else if c.app != nil && c.app.helpTemplate != nil {
+		tmpl = c.app.helpTemplate
+	}
+	return tmpl.Execute(w, c.HelpData())
+}
+
+// hasRequiredValidator reports whether validators contain a Required validator.
+func hasRequiredValidator(validators []Validator) bool {
+	for _, v := range validators {
+		if wrapper, ok := v.(validatorWrapper); ok {
+			if _, ok := wrapper.externalValidator.(*validator.RequiredValidator); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package cliz
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func newHelpTestCli() *Cli {
+	cli := NewCli("myapp", "Manage things", "1.0.0")
+	cli.LongDescription("Myapp manages things\nacross many hosts.")
+	var port int = 8080
+	var name string
+	var verbose bool
+	cli.Int("port", "Port to listen on", &port).Alias("port", "p").Env("port", "MYAPP_PORT")
+	cli.String("name", "Your name", &name, Required())
+	cli.Bool("verbose", "Verbose output", &verbose)
+	cli.RootCommand().DeprecateFlag("name", "use --user instead")
+	cli.RootCommand().Example("myapp server\nmyapp --port 80")
+	cli.NewSubCommand("server", "Run the server").Aliases("srv").Group("Management Commands")
+	cli.NewSubCommand("version", "Show the version")
+	cli.NewSubCommand("secret", "Hidden command").Hidden(true)
+	return cli
+}
+
+func TestPrintHelpDefaultTemplate(t *testing.T) {
+	cli := newHelpTestCli()
+	out := captureStdout(t, cli.PrintHelp)
+
+	for _, want := range []string{
+		"Manage things",
+		"Myapp manages things\nacross many hosts.",
+		"Usage:\n  myapp [flags] [command]",
+		"Examples:\n  myapp server\n  myapp --port 80",
+		"Management Commands:\n  server (srv)  Run the server",
+		"Commands:\n  version       Show the version",
+		"-p, --port int     Port to listen on (default 8080) [env: MYAPP_PORT]",
+		"--name string  Your name (deprecated: use --user instead) (required)",
+		"--verbose      Verbose output\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected help to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "secret") {
+		t.Errorf("Expected hidden command to be omitted, got:\n%s", out)
+	}
+	if strings.Index(out, "Management Commands:") > strings.Index(out, "Commands:\n  version") {
+		t.Errorf("Expected groups in order of first appearance, got:\n%s", out)
+	}
+}
+
+func TestSetHelpTemplate(t *testing.T) {
+	cli := newHelpTestCli()
+	cli.SetHelpTemplate(`{{.Path}}:{{range .Flags}} {{.Name}}{{end}}`)
+	server, _ := cli.RootCommand().lookupSubCommand("server", false)
+	version, _ := cli.RootCommand().lookupSubCommand("version", false)
+	version.SetHelpTemplate(`{{.Name}} - {{.ShortDescription}}`)
+
+	if out := captureStdout(t, cli.PrintHelp); out != "myapp: help name port verbose" {
+		t.Errorf("Expected app template output, got %q", out)
+	}
+	if out := captureStdout(t, server.PrintHelp); out != "myapp server: help" {
+		t.Errorf("Expected app template for subcommand, got %q", out)
+	}
+	if out := captureStdout(t, version.PrintHelp); out != "version - Show the version" {
+		t.Errorf("Expected command template output, got %q", out)
+	}
+}
+
+func TestSetHelpTemplateInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for invalid template")
+		}
+	}()
+	NewCli("myapp", "", "").SetHelpTemplate("{{.Path")
+}
+
+func TestHelpData(t *testing.T) {
+	cli := newHelpTestCli()
+	data := cli.RootCommand().HelpData()
+	if len(data.CommandGroups) != 2 || data.CommandGroups[0].Title != "Management Commands" {
+		t.Fatalf("Unexpected command groups: %+v", data.CommandGroups)
+	}
+	if got := data.CommandGroups[0].Commands[0].Aliases; len(got) != 1 || got[0] != "srv" {
+		t.Errorf("Expected aliases [srv], got %v", got)
+	}
+	for _, f := range data.Flags {
+		if f.Name == "port" && f.Synopsis() != "-p, --port int" {
+			t.Errorf("Unexpected synopsis %q", f.Synopsis())
+		}
+		if f.Name == "name" && (!f.Required || f.Deprecated == "") {
+			t.Errorf("Expected name to be required and deprecated, got %+v", f)
+		}
+	}
+}
+
+func TestDeprecateFlagWarning(t *testing.T) {
+	cli := newHelpTestCli()
+	cli.Action(func() error { return nil })
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	err = cli.Run("--name", "bob")
+	os.Stderr = stderr
+	w.Close()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out, _ := io.ReadAll(r)
+	if !strings.Contains(string(out), "Flag --name has been deprecated, use --user instead") {
+		t.Errorf("Expected deprecation warning, got %q", out)
+	}
+}
+
+func TestDeprecateFlagUndefined(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for undefined flag")
+		}
+	}()
+	NewCli("myapp", "", "").RootCommand().DeprecateFlag("missing", "gone")
+}
+
+func TestHelpArguments(t *testing.T) {
+	type Args struct {
+		Src   string   `position:"0" name:"src" description:"Source file" validate:"required"`
+		Dst   string   `position:"1" description:"Destination" default:"."`
+		Mode  string   `position:"2" description:"Copy mode"`
+		Extra []string `position:"3..." name:"extra" description:"Extra files"`
+	}
+	var args Args
+	cli := NewCli("myapp", "Manage things", "1.0.0")
+	copyCmd := cli.NewSubCommand("copy", "Copy files").AddPositionalArgs(&args)
+
+	if line := copyCmd.UseLine(); line != "myapp copy [flags] <src> [dst] [mode] [extra...]" {
+		t.Fatalf("Unexpected usage line %q", line)
+	}
+	out := captureStdout(t, copyCmd.PrintHelp)
+	for _, want := range []string{
+		"Usage:\n  myapp copy [flags] <src> [dst] [mode] [extra...]",
+		"Arguments:\n  <src>       Source file\n  [dst]       Destination (default .)\n  [mode]      Copy mode\n  [extra...]  Extra files\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected help to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	err := cli.Run("copy")
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) || validationErrs[0].Arg != "src" || err.Error() != "src: field is required" {
+		t.Fatalf("Expected a validation error naming src, got %v", err)
+	}
+}
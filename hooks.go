@@ -0,0 +1,88 @@
+package cliz
+
+import (
+	"context"
+	"errors"
+)
+
+// PreRun sets a hook that runs before the command's action.
+// It runs after flags are parsed and validated, so it can read flag values.
+// If the hook returns an error, the action and PostRun hook are skipped, but the
+// persistent post-run hooks of ancestors whose pre-run hooks succeeded still run.
+func (c *Command) PreRun(hook ActionContext) *Command {
+	c.preRun = hook
+	return c
+}
+
+// PostRun sets a hook that runs after the command's action, even if the
+// action returned an error. Errors from the action and the hook are joined.
+func (c *Command) PostRun(hook ActionContext) *Command {
+	c.postRun = hook
+	return c
+}
+
+// PersistentPreRun sets a hook that runs before the action of this command
+// and of every descendant. Persistent pre-run hooks run from the root command
+// down to the executed command, before its PreRun hook.
+func (c *Command) PersistentPreRun(hook ActionContext) *Command {
+	c.persistentPreRun = hook
+	return c
+}
+
+// PersistentPostRun sets a hook that runs after the action of this command
+// and of every descendant, even if the action returned an error.
+// Persistent post-run hooks run from the executed command up to the root
+// command, after its PostRun hook. If a pre-run hook fails, only the commands
+// whose persistent pre-run hooks already succeeded run their post-run hooks,
+// so resources opened in a pre-run hook are always released.
+func (c *Command) PersistentPostRun(hook ActionContext) *Command {
+	c.persistentPostRun = hook
+	return c
+}
+
+// Parent returns the command this command was added to, or nil for the root command.
+func (c *Command) Parent() *Command {
+	return c.parent
+}
+
+// lineage returns the command and its ancestors, starting from the root command.
+func (c *Command) lineage() []*Command {
+	var commands []*Command
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		commands = append([]*Command{cmd}, commands...)
+	}
+	return commands
+}
+
+// runAction runs the command's action surrounded by its pre-run and post-run hooks.
+// Errors from the action and the post-run hooks are joined.
+func (c *Command) runAction(ctx context.Context, args []string) error {
+	c.ctx = ctx
+	lineage := c.lineage()
+	var err error
+	ran := 0 // Number of commands in lineage whose persistent pre-run hooks succeeded
+	for _, cmd := range lineage {
+		if cmd.persistentPreRun != nil {
+			if err = cmd.persistentPreRun(ctx, c, args); err != nil {
+				break
+			}
+		}
+		ran++
+	}
+	if err == nil && c.preRun != nil {
+		err = c.preRun(ctx, c, args)
+	}
+
+	if err == nil {
+		err = c.actionCallback(ctx, c, args)
+		if c.postRun != nil {
+			err = errors.Join(err, c.postRun(ctx, c, args))
+		}
+	}
+	for i := ran - 1; i >= 0; i-- {
+		if hook := lineage[i].persistentPostRun; hook != nil {
+			err = errors.Join(err, hook(ctx, c, args))
+		}
+	}
+	return err
+}
@@ -0,0 +1,130 @@
+package cliz
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestHooksOrder(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var calls []string
+	hook := func(name string) ActionContext {
+		return func(ctx context.Context, cmd *Command, args []string) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+	var url string
+	db := cli.NewSubCommand("db", "database commands")
+	db.String("url", "database url", &url)
+	db.PersistentPreRun(func(ctx context.Context, cmd *Command, args []string) error {
+		if url != "postgres://localhost" {
+			t.Fatalf("Expected flag to be parsed before hooks, got %q", url)
+		}
+		calls = append(calls, "db-persistent-pre")
+		return nil
+	})
+	db.PersistentPostRun(hook("db-persistent-post"))
+	cli.RootCommand().PersistentPreRun(hook("root-persistent-pre"))
+	cli.RootCommand().PersistentPostRun(hook("root-persistent-post"))
+
+	migrate := db.NewSubCommandInheritFlags("migrate", "run migrations")
+	migrate.PreRun(hook("pre"))
+	migrate.PostRun(hook("post"))
+	migrate.ActionContext(hook("action"))
+
+	err := cli.Run("db", "migrate", "--url", "postgres://localhost")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "root-persistent-pre db-persistent-pre pre action post db-persistent-post root-persistent-post"
+	if got := strings.Join(calls, " "); got != expected {
+		t.Fatalf("Expected hooks %q, got %q", expected, got)
+	}
+}
+
+func TestPostRunAfterActionError(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	actionErr := errors.New("action failed")
+	closeErr := errors.New("close failed")
+	closed := false
+	db := cli.NewSubCommand("db", "database commands")
+	db.PersistentPostRun(func(ctx context.Context, cmd *Command, args []string) error {
+		closed = true
+		return closeErr
+	})
+	db.NewSubCommand("migrate", "run migrations").ActionContext(func(ctx context.Context, cmd *Command, args []string) error {
+		return actionErr
+	})
+
+	err := cli.Run("db", "migrate")
+	if !closed {
+		t.Fatalf("Expected post-run hook to run after a failed action")
+	}
+	if !errors.Is(err, actionErr) || !errors.Is(err, closeErr) {
+		t.Fatalf("Expected both errors to be returned, got %v", err)
+	}
+}
+
+func TestPreRunError(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	preErr := errors.New("pre failed")
+	called := false
+	cli.RootCommand().PreRun(func(ctx context.Context, cmd *Command, args []string) error {
+		return preErr
+	})
+	cli.Action(func() error {
+		called = true
+		return nil
+	})
+	err := cli.Run([]string{}...)
+	if !errors.Is(err, preErr) {
+		t.Fatalf("Expected pre-run error, got %v", err)
+	}
+	if called {
+		t.Fatalf("Expected action to be skipped")
+	}
+}
+
+func TestPostRunAfterPreRunError(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	preErr := errors.New("pre failed")
+	var calls []string
+	hook := func(name string, err error) ActionContext {
+		return func(ctx context.Context, cmd *Command, args []string) error {
+			calls = append(calls, name)
+			return err
+		}
+	}
+	cli.RootCommand().PersistentPostRun(hook("root-close", nil))
+	db := cli.NewSubCommand("db", "database commands")
+	db.PersistentPreRun(hook("open", nil))
+	db.PersistentPostRun(hook("close", nil))
+	migrate := db.NewSubCommand("migrate", "run migrations")
+	migrate.PersistentPreRun(hook("lock", preErr))
+	migrate.PersistentPostRun(hook("unlock", nil))
+	migrate.PostRun(hook("post", nil))
+	migrate.ActionContext(hook("action", nil))
+
+	err := cli.Run("db", "migrate")
+	if !errors.Is(err, preErr) {
+		t.Fatalf("Expected pre-run error, got %v", err)
+	}
+	expected := "open lock close root-close"
+	if got := strings.Join(calls, " "); got != expected {
+		t.Fatalf("Expected hooks %q, got %q", expected, got)
+	}
+
+	calls = nil
+	migrate.PersistentPreRun(nil).PreRun(hook("pre", preErr))
+	err = cli.Run("db", "migrate")
+	if !errors.Is(err, preErr) {
+		t.Fatalf("Expected pre-run error, got %v", err)
+	}
+	expected = "open pre unlock close root-close"
+	if got := strings.Join(calls, " "); got != expected {
+		t.Fatalf("Expected hooks %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,176 @@
+package cliz
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ManOptions configures man page generation.
+type ManOptions struct {
+	Section string    // Manual section, "1" if empty
+	Date    time.Time // Date shown in the footer; see GenerateManPages
+	Source  string    // Footer source, the application name and version if empty
+	Manual  string    // Manual title shown in the header, "User Commands" if empty
+}
+
+// GenerateManPages writes a roff man page for every visible command into dir,
+// named after the command path, e.g. myapp-server-start.1.
+// Pages list the description, flags with their defaults, environment variables
+// and validation rules, subcommands, and related pages under SEE ALSO.
+// For reproducible builds the date is taken from options.Date, then from the
+// SOURCE_DATE_EPOCH environment variable, and only then from the current time.
+func (c *Cli) GenerateManPages(dir string, options *ManOptions) error {
+	opts := ManOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.Section == "" {
+		opts.Section = "1"
+	}
+	if opts.Source == "" {
+		opts.Source = strings.TrimSpace(c.Name() + " " + c.Version())
+	}
+	if opts.Manual == "" {
+		opts.Manual = "User Commands"
+	}
+	if opts.Date.IsZero() {
+		opts.Date = time.Now()
+		if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+			seconds, err := strconv.ParseInt(epoch, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %v", epoch, err)
+			}
+			opts.Date = time.Unix(seconds, 0).UTC()
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return c.rootCommand.walkVisible(func(cmd *Command) error {
+		path := filepath.Join(dir, cmd.docName()+"."+opts.Section)
+		return os.WriteFile(path, []byte(cmd.manPage(&opts)), 0o644)
+	})
+}
+
+// manPage renders the man page of the command.
+func (c *Command) manPage(opts *ManOptions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, ".TH %q %q %q %q %q\n", strings.ToUpper(c.docName()), opts.Section,
+		opts.Date.Format("Jan 2006"), opts.Source, opts.Manual)
+
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", roffEscape(c.docName()), roffEscape(c.shortdescription))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, "%s\n", roffEscape(c.UseLine()))
+
+	if description := c.description(); description != "" {
+		b.WriteString(".SH DESCRIPTION\n")
+		b.WriteString(roffText(description))
+	}
+
+	if c.examples != "" {
+		b.WriteString(".SH EXAMPLES\n.nf\n")
+		b.WriteString(roffText(c.examples))
+		b.WriteString(".fi\n")
+	}
+
+	if args := c.argumentDocs(); len(args) > 0 {
+		b.WriteString(".SH ARGUMENTS\n")
+		for _, arg := range args {
+			b.WriteString(".TP\n")
+			fmt.Fprintf(&b, "\\fI%s\\fR\n", roffEscape(arg.Usage))
+			b.WriteString(roffText(arg.Description))
+			if arg.Default != "" {
+				fmt.Fprintf(&b, "Default: %s.\n", roffEscape(arg.Default))
+			}
+			if len(arg.Rules) > 0 {
+				fmt.Fprintf(&b, "Validation: %s.\n", roffEscape(strings.Join(arg.Rules, ", ")))
+			}
+		}
+	}
+
+	if flags := c.flagDocs(); len(flags) > 0 {
+		b.WriteString(".SH OPTIONS\n")
+		for _, f := range flags {
+			b.WriteString(".TP\n")
+			if f.Short != "" {
+				fmt.Fprintf(&b, "\\fB\\-%s\\fR, ", roffEscape(f.Short))
+			}
+			fmt.Fprintf(&b, "\\fB\\-\\-%s\\fR", roffEscape(f.Name))
+			if !f.Bool && f.Type != "" {
+				fmt.Fprintf(&b, " \\fI%s\\fR", roffEscape(f.Type))
+			}
+			b.WriteString("\n")
+			b.WriteString(roffText(f.Description()))
+			if f.Default != "" {
+				fmt.Fprintf(&b, "Default: %s.\n", roffEscape(f.Default))
+			}
+			if len(f.Env) > 0 {
+				fmt.Fprintf(&b, "Environment: %s.\n", roffEscape(strings.Join(f.Env, ", ")))
+			}
+			if len(f.Rules) > 0 {
+				fmt.Fprintf(&b, "Validation: %s.\n", roffEscape(strings.Join(f.Rules, ", ")))
+			}
+		}
+	}
+
+	if commands := c.visibleSubCommands(); len(commands) > 0 {
+		b.WriteString(".SH COMMANDS\n")
+		for _, cmd := range commands {
+			b.WriteString(".TP\n")
+			fmt.Fprintf(&b, ".B %s\n", roffEscape(cmd.name))
+			b.WriteString(roffText(cmd.shortdescription))
+		}
+	}
+
+	var related []string
+	if c.parent != nil {
+		related = append(related, c.parent.docName())
+	}
+	for _, cmd := range c.visibleSubCommands() {
+		related = append(related, cmd.docName())
+	}
+	if len(related) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		for i, name := range related {
+			fmt.Fprintf(&b, ".BR %s (%s)", roffEscape(name), opts.Section)
+			if i < len(related)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// roffEscape escapes backslashes and dashes for use in roff text.
+func roffEscape(s string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+}
+
+// roffText escapes a block of text, protecting lines that would otherwise be
+// read as roff requests and turning blank lines into paragraph breaks.
+func roffText(s string) string {
+	if s == "" {
+		return ""
+	}
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
+		line = strings.TrimRight(line, " \t")
+		switch {
+		case line == "":
+			b.WriteString(".PP\n")
+			continue
+		case strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'"):
+			b.WriteString(`\&`)
+		}
+		b.WriteString(roffEscape(line) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package cliz
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateManPages(t *testing.T) {
+	cli := NewCli("myapp", "Manage things", "1.2.0")
+	cli.LongDescription("Myapp manages things.\n\n.Lines starting with a dot are escaped.")
+	var port int
+	var verbose bool
+	server := cli.NewSubCommand("server", "Run the server")
+	server.Int("port", "Server port", &port, Range(1, 65535)).Alias("port", "p").Env("port", "MYAPP_PORT")
+	server.Bool("verbose", "Verbose output", &verbose)
+	server.NewSubCommand("start", "Start the server")
+	cli.NewSubCommand("secret", "Hidden command").Hidden(true)
+
+	dir := t.TempDir()
+	date := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	err := cli.GenerateManPages(dir, &ManOptions{Date: date})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"myapp.1", "myapp-server.1", "myapp-server-start.1"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("Expected man page %s: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "myapp-secret.1")); err == nil {
+		t.Fatalf("Expected no man page for the hidden command")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "myapp-server.1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	page := string(data)
+	for _, expected := range []string{
+		`.TH "MYAPP-SERVER" "1" "Mar 2024" "myapp 1.2.0" "User Commands"`,
+		`myapp\-server \- Run the server`,
+		`\fB\-p\fR, \fB\-\-port\fR \fIint\fR`,
+		"Environment: MYAPP_PORT.",
+		`Validation: range=1\-65535.`,
+		".B start",
+		".BR myapp (1),\n.BR myapp\\-server\\-start (1)\n",
+	} {
+		if !strings.Contains(page, expected) {
+			t.Fatalf("Expected man page to contain %q, got:\n%s", expected, page)
+		}
+	}
+
+	data, err = os.ReadFile(filepath.Join(dir, "myapp.1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), "\\&.Lines starting") {
+		t.Fatalf("Expected lines starting with a dot to be escaped, got:\n%s", data)
+	}
+}
+
+func TestGenerateManPagesSourceDateEpoch(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "0")
+	cli := NewCli("myapp", "Manage things", "1.2.0")
+	dir := t.TempDir()
+	err := cli.GenerateManPages(dir, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "myapp.1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"Jan 1970"`) {
+		t.Fatalf("Expected date from SOURCE_DATE_EPOCH, got:\n%s", data)
+	}
+}
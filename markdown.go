@@ -0,0 +1,109 @@
+package cliz
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DocOptions configures Markdown and reStructuredText generation.
+type DocOptions struct {
+	// LinkHandler turns the file name of a linked command page, such as
+	// "myapp-server.md", into the link target. Links point to the file
+	// name itself if it is nil.
+	LinkHandler func(filename string) string
+}
+
+// link returns the target of a link to the page of cmd.
+func (o *DocOptions) link(cmd *Command, ext string) string {
+	filename := cmd.docName() + ext
+	if o != nil && o.LinkHandler != nil {
+		return o.LinkHandler(filename)
+	}
+	return filename
+}
+
+// GenerateMarkdown writes a Markdown page for every visible command into dir,
+// named after the command path, e.g. myapp-server-start.md.
+// Pages contain the usage line, the description, examples, a flag table with
+// types, defaults, environment variables and validation rules, and links to
+// the parent and child commands.
+func (c *Cli) GenerateMarkdown(dir string, options *DocOptions) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return c.rootCommand.walkVisible(func(cmd *Command) error {
+		path := filepath.Join(dir, cmd.docName()+".md")
+		return os.WriteFile(path, []byte(cmd.markdownPage(options)), 0o644)
+	})
+}
+
+// markdownPage renders the Markdown page of the command.
+func (c *Command) markdownPage(options *DocOptions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", c.commandPath)
+	if c.shortdescription != "" {
+		fmt.Fprintf(&b, "%s\n\n", c.shortdescription)
+	}
+
+	b.WriteString("### Synopsis\n\n")
+	if c.longdescription != "" {
+		fmt.Fprintf(&b, "%s\n\n", strings.TrimSpace(c.longdescription))
+	}
+	fmt.Fprintf(&b, "```\n%s\n```\n\n", c.UseLine())
+
+	if c.examples != "" {
+		fmt.Fprintf(&b, "### Examples\n\n```\n%s\n```\n\n", strings.Trim(c.examples, "\n"))
+	}
+
+	if args := c.argumentDocs(); len(args) > 0 {
+		b.WriteString("### Arguments\n\n")
+		b.WriteString("| Argument | Default | Validation | Description |\n")
+		b.WriteString("|----------|---------|------------|-------------|\n")
+		for _, arg := range args {
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", arg.Usage, markdownCell(arg.Default),
+				markdownCell(strings.Join(arg.Rules, ", ")), markdownCell(arg.Description))
+		}
+		b.WriteString("\n")
+	}
+
+	if flags := c.flagDocs(); len(flags) > 0 {
+		b.WriteString("### Flags\n\n")
+		b.WriteString("| Flag | Type | Default | Environment | Validation | Description |\n")
+		b.WriteString("|------|------|---------|-------------|------------|-------------|\n")
+		for _, f := range flags {
+			name := "`--" + f.Name + "`"
+			if f.Short != "" {
+				name = "`-" + f.Short + "`, " + name
+			}
+			env := make([]string, len(f.Env))
+			for i, v := range f.Env {
+				env[i] = "`" + v + "`"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n", name, markdownCell(f.Type), markdownCell(f.Default),
+				strings.Join(env, ", "), markdownCell(strings.Join(f.Rules, ", ")), markdownCell(f.Description()))
+		}
+		b.WriteString("\n")
+	}
+
+	if commands := c.visibleSubCommands(); len(commands) > 0 {
+		b.WriteString("### Commands\n\n")
+		for _, cmd := range commands {
+			fmt.Fprintf(&b, "* [%s](%s) - %s\n", cmd.commandPath, options.link(cmd, ".md"), cmd.shortdescription)
+		}
+		b.WriteString("\n")
+	}
+
+	if c.parent != nil {
+		b.WriteString("### See also\n\n")
+		fmt.Fprintf(&b, "* [%s](%s) - %s\n", c.parent.commandPath, options.link(c.parent, ".md"), c.parent.shortdescription)
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// markdownCell escapes text for use in a Markdown table cell.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
@@ -0,0 +1,102 @@
+package cliz
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newDocsTestCli() *Cli {
+	cli := NewCli("myapp", "Manage things", "1.2.0")
+	var port int
+	var mode string
+	server := cli.NewSubCommand("server", "Run the server")
+	server.SetLongDescription("Runs the HTTP server.")
+	server.Example("myapp server --port 8080")
+	server.Int("port", "Server port", &port, Range(1, 65535)).Alias("port", "p").Env("port", "MYAPP_PORT")
+	mode = "fast"
+	server.String("mode", "Mode a|b", &mode)
+	server.NewSubCommand("start", "Start the server")
+	cli.NewSubCommand("secret", "Hidden command").Hidden(true)
+	return cli
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	cli := newDocsTestCli()
+	dir := t.TempDir()
+	err := cli.GenerateMarkdown(dir, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "myapp-secret.md")); err == nil {
+		t.Fatalf("Expected no page for the hidden command")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "myapp-server.md"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	page := string(data)
+	for _, expected := range []string{
+		"## myapp server\n\nRun the server\n",
+		"Runs the HTTP server.\n\n```\nmyapp server [flags] [command]\n```",
+		"### Examples\n\n```\nmyapp server --port 8080\n```",
+		"| `--mode` | string | fast |  |  | Mode a\\|b |",
+		"| `-p`, `--port` | int |  | `MYAPP_PORT` | range=1-65535 | Server port |",
+		"* [myapp server start](myapp-server-start.md) - Start the server",
+		"### See also\n\n* [myapp](myapp.md) - Manage things",
+	} {
+		if !strings.Contains(page, expected) {
+			t.Fatalf("Expected page to contain %q, got:\n%s", expected, page)
+		}
+	}
+}
+
+func TestGenerateMarkdownLinkHandler(t *testing.T) {
+	cli := newDocsTestCli()
+	dir := t.TempDir()
+	err := cli.GenerateMarkdown(dir, &DocOptions{
+		LinkHandler: func(filename string) string {
+			return "/docs/" + strings.TrimSuffix(filename, ".md") + "/"
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "myapp.md"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), "* [myapp server](/docs/myapp-server/) - Run the server") {
+		t.Fatalf("Expected rewritten link, got:\n%s", data)
+	}
+}
+
+func TestArgumentDocs(t *testing.T) {
+	type Args struct {
+		Src  string   `position:"0" description:"Source file" validate:"required"`
+		Dst  string   `position:"1" description:"Destination"`
+		Rest []string `position:"rest" name:"files" description:"More files"`
+	}
+	var args Args
+	cli := NewCli("myapp", "Manage things", "1.2.0")
+	copyCmd := cli.NewSubCommand("copy", "Copy files").AddPositionalArgs(&args)
+
+	markdown := copyCmd.markdownPage(&DocOptions{})
+	for _, want := range []string{
+		"myapp copy [flags] <src> [dst] [files...]",
+		"| `<src>` |  | required | Source file |",
+		"| `[dst]` |  |  | Destination |",
+		"| `[files...]` |  |  | More files |",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected Markdown to contain %q, got:\n%s", want, markdown)
+		}
+	}
+	if rest := copyCmd.restPage(&DocOptions{}); !strings.Contains(rest, "Arguments\n---------") || !strings.Contains(rest, "``<src>``") {
+		t.Errorf("Expected an Arguments section, got:\n%s", rest)
+	}
+	if man := copyCmd.manPage(&ManOptions{Section: "1"}); !strings.Contains(man, ".SH ARGUMENTS\n.TP\n\\fI<src>\\fR\nSource file\n") || !strings.Contains(man, ".TP\n\\fI[dst]\\fR\nDestination\n") {
+		t.Errorf("Expected an ARGUMENTS section, got:\n%s", man)
+	}
+}
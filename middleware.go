@@ -0,0 +1,52 @@
+package cliz
+
+import (
+	"context"
+)
+
+// Middleware wraps the execution of a command.
+// It receives the next handler in the chain and returns a handler that
+// usually does some work before and after calling next. Handlers are called
+// with the resolved command and its positional arguments, after flags are
+// parsed and validated.
+type Middleware func(next ActionContext) ActionContext
+
+// Use adds middleware that wraps the execution of this command and every
+// descendant, including their pre-run and post-run hooks.
+// Middleware runs in the order it was added, from the root command down.
+func (c *Command) Use(middleware ...Middleware) *Command {
+	c.middleware = append(c.middleware, middleware...)
+	return c
+}
+
+// Use adds middleware that wraps the execution of every command.
+// Application middleware runs before any command middleware.
+func (c *Cli) Use(middleware ...Middleware) *Cli {
+	c.middleware = append(c.middleware, middleware...)
+	return c
+}
+
+// middlewareChain returns the middleware that applies to the command:
+// application middleware first, then command middleware from the root down.
+func (c *Command) middlewareChain() []Middleware {
+	var chain []Middleware
+	if c.app != nil {
+		chain = append(chain, c.app.middleware...)
+	}
+	for _, cmd := range c.lineage() {
+		chain = append(chain, cmd.middleware...)
+	}
+	return chain
+}
+
+// handler returns the command's action and hooks wrapped in its middleware.
+func (c *Command) handler() ActionContext {
+	handler := func(ctx context.Context, cmd *Command, args []string) error {
+		return cmd.runAction(ctx, args)
+	}
+	chain := c.middlewareChain()
+	for i := len(chain) - 1; i >= 0; i-- {
+		handler = chain[i](handler)
+	}
+	return handler
+}
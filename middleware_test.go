@@ -0,0 +1,66 @@
+package cliz
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next ActionContext) ActionContext {
+			return func(ctx context.Context, cmd *Command, args []string) error {
+				calls = append(calls, name+":"+cmd.CommandPath()+":"+strings.Join(args, ","))
+				err := next(ctx, cmd, args)
+				calls = append(calls, "/"+name)
+				return err
+			}
+		}
+	}
+	cli.Use(record("app"))
+	cli.RootCommand().Use(record("root"))
+	db := cli.NewSubCommand("db", "database commands")
+	db.Use(record("db1"), record("db2"))
+	db.PreRun(func(ctx context.Context, cmd *Command, args []string) error {
+		calls = append(calls, "pre")
+		return nil
+	})
+	db.Action(func() error {
+		calls = append(calls, "action")
+		return nil
+	})
+
+	err := cli.Run("db", "users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "app:test-app db:users root:test-app db:users db1:test-app db:users db2:test-app db:users pre action /db2 /db1 /root /app"
+	if got := strings.Join(calls, " "); got != expected {
+		t.Fatalf("Expected calls %q, got %q", expected, got)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	denied := errors.New("permission denied")
+	cli.Use(func(next ActionContext) ActionContext {
+		return func(ctx context.Context, cmd *Command, args []string) error {
+			return denied
+		}
+	})
+	called := false
+	cli.Action(func() error {
+		called = true
+		return nil
+	})
+	err := cli.Run([]string{}...)
+	if !errors.Is(err, denied) {
+		t.Fatalf("Expected middleware error, got %v", err)
+	}
+	if called {
+		t.Fatalf("Expected action to be skipped")
+	}
+}
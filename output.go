@@ -0,0 +1,51 @@
+package cliz
+
+import (
+	"io"
+	"os"
+)
+
+// SetOutput sets the writer for help, banners, completion scripts and other
+// regular output. Passing nil restores os.Stdout.
+func (c *Cli) SetOutput(w io.Writer) *Cli {
+	c.out = w
+	return c
+}
+
+// SetErrOutput sets the writer for error messages and warnings.
+// Passing nil restores os.Stderr.
+func (c *Cli) SetErrOutput(w io.Writer) *Cli {
+	c.errOut = w
+	return c
+}
+
+// SetInput sets the reader actions should read input from.
+// Passing nil restores os.Stdin.
+func (c *Cli) SetInput(r io.Reader) *Cli {
+	c.in = r
+	return c
+}
+
+// Output returns the writer set with Cli.SetOutput, or os.Stdout.
+func (c *Command) Output() io.Writer {
+	if c.app != nil && c.app.out != nil {
+		return c.app.out
+	}
+	return os.Stdout
+}
+
+// ErrOutput returns the writer set with Cli.SetErrOutput, or os.Stderr.
+func (c *Command) ErrOutput() io.Writer {
+	if c.app != nil && c.app.errOut != nil {
+		return c.app.errOut
+	}
+	return os.Stderr
+}
+
+// Input returns the reader set with Cli.SetInput, or os.Stdin.
+func (c *Command) Input() io.Reader {
+	if c.app != nil && c.app.in != nil {
+		return c.app.in
+	}
+	return os.Stdin
+}
@@ -0,0 +1,104 @@
+package cliz
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSetOutput(t *testing.T) {
+	var out, errOut bytes.Buffer
+	cli := newHelpTestCli()
+	cli.SetOutput(&out).SetErrOutput(&errOut)
+	cli.Action(func() error { return nil })
+
+	if err := cli.Run("--help"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Usage:\n  myapp [flags] [command]") {
+		t.Errorf("Expected help in output writer, got %q", out.String())
+	}
+
+	cli = newHelpTestCli()
+	cli.SetOutput(&out).SetErrOutput(&errOut)
+	cli.Action(func() error { return nil })
+	if err := cli.Run("--name", "bob"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(errOut.String(), "Flag --name has been deprecated") {
+		t.Errorf("Expected deprecation warning in error writer, got %q", errOut.String())
+	}
+
+	out.Reset()
+	cli.PrintBanner()
+	if out.String() != "myapp 1.0.0 - Manage things\n\n" {
+		t.Errorf("Unexpected banner %q", out.String())
+	}
+}
+
+func TestSetOutputConcurrent(t *testing.T) {
+	for _, name := range []string{"first", "second", "third"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			cli := NewCli(name, "Concurrent app", "1.0.0").SetOutput(&out)
+			cli.PrintHelp()
+			if !strings.HasPrefix(out.String(), name+"\n") {
+				t.Errorf("Expected help for %s, got %q", name, out.String())
+			}
+		})
+	}
+}
+
+func TestSetInput(t *testing.T) {
+	var out bytes.Buffer
+	cli := NewCli("myapp", "", "").SetInput(strings.NewReader("hello")).SetOutput(&out)
+	cli.NewSubCommand("shout", "Upper-case input").ActionContext(func(ctx context.Context, cmd *Command, args []string) error {
+		data, err := io.ReadAll(cmd.Input())
+		if err != nil {
+			return err
+		}
+		_, err = cmd.Output().Write(bytes.ToUpper(data))
+		return err
+	})
+
+	if err := cli.Run("shout"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.String() != "HELLO" {
+		t.Errorf("Expected HELLO, got %q", out.String())
+	}
+}
+
+func TestSetOutputAddedCommand(t *testing.T) {
+	var out bytes.Buffer
+	cmd := NewCommand("remote", "Manage remotes")
+	cmd.NewSubCommand("add", "Add a remote").ActionContext(func(ctx context.Context, cmd *Command, args []string) error {
+		_, err := io.WriteString(cmd.Output(), cmd.CommandPath())
+		return err
+	})
+	cli := NewCli("myapp", "", "")
+	cli.AddCommand(cmd)
+	cli.SetOutput(&out)
+
+	if err := cli.Run("remote", "add"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.String() != "myapp remote add" {
+		t.Errorf("Expected myapp remote add in output writer, got %q", out.String())
+	}
+}
+
+func TestCompletionOutput(t *testing.T) {
+	var out bytes.Buffer
+	cli := NewCli("myapp", "", "").EnableCompletion().SetOutput(&out)
+
+	if err := cli.Run("completion", "bash"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "complete -o default -F _myapp myapp") {
+		t.Errorf("Expected bash script in output writer, got %q", out.String())
+	}
+}
@@ -0,0 +1,159 @@
+package cliz
+
+import (
+	"flag"
+	"strings"
+	"unicode/utf8"
+)
+
+// boolFlag is implemented by flag values that can be given without a value.
+type boolFlag interface {
+	IsBoolFlag() bool
+}
+
+// isBoolFlag reports whether the flag value can be given without a value.
+func isBoolFlag(value flag.Value) bool {
+	b, ok := value.(boolFlag)
+	return ok && b.IsBoolFlag()
+}
+
+// parseArgs parses the flags in args, setting their values, and returns the
+// remaining positional arguments in order.
+// Long flags are accepted as --name, --name=value and --name value, as well as
+// the single dash -name form. Short aliases follow POSIX conventions: -v,
+// grouped booleans such as -abc, and values given as -ofile, -o=file or -o file.
+// Everything after a bare "--" is treated as positional.
+func (c *Command) parseArgs(args []string) ([]string, error) {
+	var positionalArgs []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positionalArgs = append(positionalArgs, args[i+1:]...)
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			positionalArgs = append(positionalArgs, arg)
+			continue
+		}
+
+		var consumed int
+		var err error
+		if strings.HasPrefix(arg, "--") {
+			consumed, err = c.parseLongFlag(arg[2:], args[i+1:])
+		} else if name, _, _ := strings.Cut(arg[1:], "="); c.flags.Lookup(name) != nil {
+			consumed, err = c.parseLongFlag(arg[1:], args[i+1:])
+		} else {
+			consumed, err = c.parseShortFlags(arg[1:], args[i+1:])
+		}
+		if err != nil {
+			return nil, err
+		}
+		i += consumed
+	}
+	return positionalArgs, nil
+}
+
+// parseLongFlag parses a single long flag without its leading dashes.
+// It returns the number of following arguments consumed as the flag value.
+func (c *Command) parseLongFlag(arg string, rest []string) (int, error) {
+	name, value, hasValue := strings.Cut(arg, "=")
+	f := c.flags.Lookup(name)
+	if f == nil {
+		return 0, c.unknownFlag(name)
+	}
+	if hasValue {
+		return 0, c.setFlag(f, name, value)
+	}
+	if isBoolFlag(f.Value) {
+		return 0, c.setFlag(f, name, "true")
+	}
+	if len(rest) == 0 {
+		return 0, &MissingValueError{Command: c.commandPath, Flag: name}
+	}
+	return 1, c.setFlag(f, name, rest[0])
+}
+
+// parseShortFlags parses a group of short flags without the leading dash.
+// Boolean flags may be grouped; the first non-boolean flag takes the rest of
+// the group, or the next argument, as its value.
+// It returns the number of following arguments consumed as a flag value.
+func (c *Command) parseShortFlags(arg string, rest []string) (int, error) {
+	for i, r := range arg {
+		short := string(r)
+		name, ok := c.shortFlags[short]
+		if !ok {
+			if i == 0 && len(arg) > len(short) {
+				// Not a group of short flags, most likely a misspelled -name flag
+				name, _, _ = strings.Cut(arg, "=")
+				return 0, c.unknownFlag(name)
+			}
+			return 0, c.unknownFlag(short)
+		}
+		f := c.flags.Lookup(name)
+		value := arg[i+utf8.RuneLen(r):]
+		hasValue := strings.HasPrefix(value, "=")
+		if hasValue {
+			return 0, c.setFlag(f, short, value[1:])
+		}
+		if isBoolFlag(f.Value) {
+			if err := c.setFlag(f, short, "true"); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if value != "" {
+			return 0, c.setFlag(f, short, value)
+		}
+		if len(rest) == 0 {
+			return 0, &MissingValueError{Command: c.commandPath, Flag: short}
+		}
+		return 1, c.setFlag(f, short, rest[0])
+	}
+	return 0, nil
+}
+
+// setFlag sets the value of a flag, marking it as set in the flag set.
+// The given name is the one used on the command line and appears in errors.
+func (c *Command) setFlag(f *flag.Flag, name, value string) error {
+	if err := c.flags.Set(f.Name, value); err != nil {
+		return c.invalidValue(f, name, value, valueSource{kind: SourceArgv}, err)
+	}
+	c.flagSources[f.Name] = valueSource{kind: SourceArgv}
+	return nil
+}
+
+// invalidValue builds an InvalidValueError for a value of flag f given as name.
+func (c *Command) invalidValue(f *flag.Flag, name, value string, source valueSource, err error) *InvalidValueError {
+	var typ string
+	if v, ok := f.Value.(typedValue); ok {
+		typ = v.Type()
+	}
+	return &InvalidValueError{
+		Command:  c.commandPath,
+		Flag:     name,
+		Value:    value,
+		Type:     typ,
+		Source:   source.kind,
+		Location: source.location,
+		Err:      err,
+	}
+}
+
+// Alias adds a single character short form for an existing flag,
+// so that -v can be used in place of --verbose.
+// It panics if the flag is not defined or the short name is already taken.
+func (c *Command) Alias(flagName, short string) *Command {
+	if c.flags.Lookup(flagName) == nil {
+		panic("Alias: flag '" + flagName + "' is not defined")
+	}
+	if utf8.RuneCountInString(short) != 1 || short == "-" || short == "=" {
+		panic("Alias: short flag '" + short + "' must be a single character")
+	}
+	if existing, ok := c.shortFlags[short]; ok && existing != flagName {
+		panic("Alias: short flag '-" + short + "' is already used by '" + existing + "'")
+	}
+	c.shortFlags[short] = flagName
+	c.flagShorts[flagName] = short
+	c.checkConfigFlag()
+	return c
+}
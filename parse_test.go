@@ -0,0 +1,154 @@
+package cliz
+
+import (
+	"testing"
+)
+
+func TestAliasShortFlag(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var verbose bool
+	cli.Bool("verbose", "enable verbose mode", &verbose).Alias("verbose", "v")
+	err := cli.Run("-v")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !verbose {
+		t.Fatalf("Expected verbose to be true, got false")
+	}
+}
+
+func TestCombinedShortFlags(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var all, brief, color bool
+	cli.Bool("all", "show all", &all).Alias("all", "a")
+	cli.Bool("brief", "brief output", &brief).Alias("brief", "b")
+	cli.Bool("color", "colorize output", &color).Alias("color", "c")
+	err := cli.Run("-abc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !all || !brief || !color {
+		t.Fatalf("Expected all flags to be true, got all=%v brief=%v color=%v", all, brief, color)
+	}
+}
+
+func TestShortFlagValueForms(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var verbose bool
+	var output []string
+	cli.Bool("verbose", "enable verbose mode", &verbose).Alias("verbose", "v")
+	cli.StringSlice("output", "output file", &output).Alias("output", "o")
+	err := cli.Run("-ofile1", "-o", "file2", "-o=file3", "-vofile4")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"file1", "file2", "file3", "file4"}
+	if len(output) != len(expected) {
+		t.Fatalf("Expected output %v, got %v", expected, output)
+	}
+	for i := range expected {
+		if output[i] != expected[i] {
+			t.Fatalf("Expected output %v, got %v", expected, output)
+		}
+	}
+	if !verbose {
+		t.Fatalf("Expected verbose to be true, got false")
+	}
+}
+
+func TestShortFlagMissingValue(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var output string
+	cli.String("output", "output file", &output).Alias("output", "o")
+	err := cli.Run("-o")
+	if err == nil {
+		t.Fatalf("Expected error, got nil")
+	}
+}
+
+func TestShortFlagUnknown(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var verbose bool
+	cli.Bool("verbose", "enable verbose mode", &verbose).Alias("verbose", "v")
+	err := cli.Run("-vx")
+	if err == nil {
+		t.Fatalf("Expected error, got nil")
+	}
+}
+
+func TestShortTag(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	type config struct {
+		Verbose bool   `name:"verbose" description:"enable verbose mode" short:"v"`
+		Name    string `name:"name" description:"set name" short:"n"`
+	}
+	var cfg config
+	cli.AddFlags(&cfg)
+	err := cli.Run("-vn", "test")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !cfg.Verbose {
+		t.Fatalf("Expected verbose to be true, got false")
+	}
+	if cfg.Name != "test" {
+		t.Fatalf("Expected name 'test', got '%s'", cfg.Name)
+	}
+}
+
+func TestShortFlagInherited(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var verbose bool
+	cli.Bool("verbose", "enable verbose mode", &verbose).Alias("verbose", "v")
+	sub := cli.NewSubCommandInheritFlags("sub", "sub command")
+	sub.Action(func() error {
+		return nil
+	})
+	err := cli.Run("sub", "-v")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !verbose {
+		t.Fatalf("Expected verbose to be true, got false")
+	}
+}
+
+func TestDoubleDashTerminator(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var verbose bool
+	cli.Bool("verbose", "enable verbose mode", &verbose).Alias("verbose", "v")
+	err := cli.Run("--", "-v", "arg")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if verbose {
+		t.Fatalf("Expected verbose to be false, got true")
+	}
+	args := cli.OtherArgs()
+	if len(args) != 2 || args[0] != "-v" || args[1] != "arg" {
+		t.Fatalf("Expected other args [-v arg], got %v", args)
+	}
+}
+
+func TestAliasPanics(t *testing.T) {
+	cmd := NewCommand("test", "test command")
+	cmd.setParentCommandPath("")
+	var verbose, version bool
+	cmd.Bool("verbose", "enable verbose mode", &verbose).Alias("verbose", "v")
+	cmd.Bool("version", "show version", &version)
+
+	for _, tc := range []struct{ flagName, short string }{
+		{"missing", "m"},
+		{"version", "vv"},
+		{"version", "v"},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Expected panic for Alias(%q, %q)", tc.flagName, tc.short)
+				}
+			}()
+			cmd.Alias(tc.flagName, tc.short)
+		}()
+	}
+}
@@ -1,103 +1,121 @@
 package cliz
 
 import (
+	"flag"
+	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
+
+	"github.com/zkep/cliz/validator"
 )
 
-// parsePositionalArgs parses the positional arguments and stores them in the provided structure.
-// The structure fields are mapped to positional arguments using the 'position' tag.
-// The position tag specifies the index of the positional argument to map to the field.
-func (c *Command) parsePositionalArgs(positionalArgs []string) error {
-	if c.positionalArgsMap == nil {
-		return nil
-	}
+// positionalArg is a struct field bound to a positional argument.
+type positionalArg struct {
+	index        int           // Position of the argument, counting from 0
+	field        string        // Name of the struct field
+	name         string        // Name shown in usage and errors, from the name tag
+	description  string        // Description shown in help, from the description tag
+	defaultValue string        // Value of the default tag
+	value        reflect.Value // The struct field
+	flagValue    flag.Value    // Parses arguments into the field
+	validators   []Validator   // Validators from the validate tag
+	variadic     bool          // Whether the argument collects all remaining arguments
+}
 
-	for i, arg := range positionalArgs {
-		pos := strconv.Itoa(i)
-		if valueRef, ok := c.positionalArgsMap[pos]; ok {
-			switch valueRef.Kind() {
-			case reflect.String:
-				valueRef.SetString(arg)
-			case reflect.Int:
-				intValue, err := strconv.Atoi(arg)
-				if err == nil {
-					valueRef.SetInt(int64(intValue))
-				}
-			case reflect.Int8:
-				intValue, err := strconv.Atoi(arg)
-				if err == nil {
-					valueRef.SetInt(int64(intValue))
-				}
-			case reflect.Int16:
-				intValue, err := strconv.Atoi(arg)
-				if err == nil {
-					valueRef.SetInt(int64(intValue))
-				}
-			case reflect.Int32:
-				intValue, err := strconv.Atoi(arg)
-				if err == nil {
-					valueRef.SetInt(int64(intValue))
-				}
-			case reflect.Int64:
-				intValue, err := strconv.Atoi(arg)
-				if err == nil {
-					valueRef.SetInt(int64(intValue))
-				}
-			case reflect.Uint:
-				// Handle negative numbers by setting to 0 (default for invalid uint conversion)
-				if uintValue, err := strconv.ParseUint(arg, 10, 64); err == nil {
-					valueRef.SetUint(uintValue)
-				}
-			case reflect.Uint8:
-				// Handle negative numbers by setting to 0 (default for invalid uint conversion)
-				if uintValue, err := strconv.ParseUint(arg, 10, 64); err == nil {
-					valueRef.SetUint(uintValue)
-				}
-			case reflect.Uint16:
-				// Handle negative numbers by setting to 0 (default for invalid uint conversion)
-				if uintValue, err := strconv.ParseUint(arg, 10, 64); err == nil {
-					valueRef.SetUint(uintValue)
-				}
-			case reflect.Uint32:
-				// Handle negative numbers by setting to 0 (default for invalid uint conversion)
-				if uintValue, err := strconv.ParseUint(arg, 10, 64); err == nil {
-					valueRef.SetUint(uintValue)
-				}
-			case reflect.Uint64:
-				// Handle negative numbers by setting to 0 (default for invalid uint conversion)
-				if uintValue, err := strconv.ParseUint(arg, 10, 64); err == nil {
-					valueRef.SetUint(uintValue)
-				}
-			case reflect.Float32:
-				floatValue, err := strconv.ParseFloat(arg, 64)
-				if err == nil {
-					valueRef.SetFloat(floatValue)
-				}
-			case reflect.Float64:
-				floatValue, err := strconv.ParseFloat(arg, 64)
-				if err == nil {
-					valueRef.SetFloat(floatValue)
+// parsePositionalArgs converts the positional arguments and stores them in
+// the fields registered with AddPositionalArgs. Arguments without a field are
+// ignored. Conversion failures are returned as InvalidValueError.
+func (c *Command) parsePositionalArgs(positionalArgs []string) error {
+	for _, arg := range c.positionals {
+		if arg.index >= len(positionalArgs) {
+			continue
+		}
+		values := positionalArgs[arg.index : arg.index+1]
+		if arg.variadic {
+			// Replace the default rather than appending to it
+			values = positionalArgs[arg.index:]
+			arg.value.Set(reflect.Zero(arg.value.Type()))
+		}
+		for i, value := range values {
+			if err := arg.flagValue.Set(value); err != nil {
+				var typ string
+				if v, ok := arg.flagValue.(typedValue); ok {
+					typ = v.Type()
 				}
-			case reflect.Bool:
-				boolValue, err := strconv.ParseBool(arg)
-				if err == nil {
-					valueRef.SetBool(boolValue)
+				return &InvalidValueError{
+					Command:  c.commandPath,
+					Position: arg.index + i,
+					Field:    arg.field,
+					Arg:      arg.name,
+					Value:    value,
+					Type:     typ,
+					Source:   SourceArgv,
+					Err:      err,
 				}
 			}
 		}
 	}
+	return nil
+}
 
+// positionalAt returns the positional argument that receives the argument at
+// index, or nil if there is none.
+func (c *Command) positionalAt(index int) *positionalArg {
+	for _, arg := range c.positionals {
+		if arg.index == index || arg.variadic && arg.index < index {
+			return arg
+		}
+	}
 	return nil
 }
 
+// validatePositionals runs the validators of every registered positional
+// argument. Arguments that were not given are validated as not set, so
+// Required fails for them.
+func (c *Command) validatePositionals(positionalArgs []string) ValidationErrors {
+	var errs ValidationErrors
+	for _, arg := range c.positionals {
+		set := arg.index < len(positionalArgs)
+		for _, valid := range arg.validators {
+			var err error
+			if v, ok := valid.(setAwareValidator); ok {
+				err = v.ValidateSet(arg.value.Interface(), set)
+			} else {
+				err = valid.Validate(arg.value.Interface())
+			}
+			if err == nil {
+				continue
+			}
+			if err, ok := err.(*validator.ValidatorError); ok {
+				err.Field = arg.name
+			}
+			errs = append(errs, &ValidationError{Arg: arg.name, Source: SourceArgv, Err: err})
+			break
+		}
+	}
+	return errs
+}
+
 // AddPositionalArgs adds positional arguments to the command based on the provided struct.
 // The struct fields are mapped to positional arguments using the 'position' tag.
 // The position tag specifies the index of the positional argument to map to the field.
+// A slice field tagged "N..." collects the argument at index N and all that
+// follow; "rest" collects the arguments after the other positional arguments.
+// Only the last positional argument may be variadic.
+// Fields may be of any type with a registered parser, and may have a 'validate'
+// tag; "required" fails when the argument is not given. The 'default' tag sets
+// the value of an argument that is not given, split on 'sep' for slices.
+// The 'name' and 'description' tags are shown in the usage line and help;
+// the name defaults to the lower-cased field name.
+// It panics if a position is invalid or used twice, a variadic argument is not
+// last or not a slice, or a field type has no parser.
 func (c *Command) AddPositionalArgs(argsStruct any) *Command {
 	value := reflect.ValueOf(argsStruct).Elem()
 	typ := value.Type()
 
+	var rest *positionalArg
 	for i := 0; i < typ.NumField(); i++ {
 		field := typ.Field(i)
 		fieldValue := value.Field(i)
@@ -106,9 +124,85 @@ func (c *Command) AddPositionalArgs(argsStruct any) *Command {
 		if position == "" {
 			continue
 		}
+		flagValue, ok := newFlagValue(fieldValue.Addr())
+		if !ok {
+			panic("AddPositionalArgs: field '" + field.Name + "' has no parser registered for type " + field.Type.String())
+		}
+		defaultValue := field.Tag.Get("default")
+		if defaultValue != "" {
+			setFieldDefaultValue(flagValue, fieldValue, defaultValue, field.Tag.Get("sep"))
+		}
+		name := field.Tag.Get("name")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		arg := &positionalArg{
+			field:        field.Name,
+			name:         name,
+			description:  field.Tag.Get("description"),
+			defaultValue: defaultValue,
+			value:        fieldValue,
+			flagValue:    flagValue,
+			validators:   parseValidateTags(field.Tag.Get("validate"), name),
+		}
+		for _, valid := range arg.validators {
+			if v, ok := valid.(crossFieldValidator); ok && v.RelatedField() != "" {
+				panic("AddPositionalArgs: rule '" + fmt.Sprint(valid) + "' of field '" + field.Name + "' is only supported by AddFlags")
+			}
+		}
 
-		c.positionalArgsMap[position] = fieldValue
+		indexTag, variadic := strings.CutSuffix(position, "...")
+		if position == "rest" {
+			indexTag, variadic = "", true
+		}
+		if variadic && field.Type.Kind() != reflect.Slice {
+			panic("AddPositionalArgs: variadic field '" + field.Name + "' must be a slice")
+		}
+		arg.variadic = variadic
+		if indexTag == "" {
+			if rest != nil {
+				panic("AddPositionalArgs: only one of '" + rest.field + "' and '" + field.Name + "' may be variadic")
+			}
+			rest = arg
+			continue
+		}
+		index, err := strconv.Atoi(indexTag)
+		if err != nil || index < 0 {
+			panic("AddPositionalArgs: field '" + field.Name + "' has invalid position '" + position + "'")
+		}
+		arg.index = index
+		c.addPositional(arg)
+	}
+	if rest != nil {
+		rest.index = 0
+		if n := len(c.positionals); n > 0 {
+			rest.index = c.positionals[n-1].index + 1
+		}
+		c.addPositional(rest)
 	}
 
 	return c
 }
+
+// addPositional registers a positional argument, keeping them sorted by index.
+// It panics if the index is taken or a variadic argument would not be last.
+func (c *Command) addPositional(arg *positionalArg) {
+	for _, other := range c.positionals {
+		if other.index == arg.index {
+			panic("AddPositionalArgs: position " + strconv.Itoa(arg.index) + " is used by both '" + other.field + "' and '" + arg.field + "'")
+		}
+		if other.variadic && other.index < arg.index || arg.variadic && arg.index < other.index {
+			panic("AddPositionalArgs: variadic field '" + variadicField(other, arg) + "' must be the last positional argument")
+		}
+	}
+	c.positionals = append(c.positionals, arg)
+	sort.Slice(c.positionals, func(i, j int) bool { return c.positionals[i].index < c.positionals[j].index })
+}
+
+// variadicField returns the name of the variadic one of two arguments.
+func variadicField(a, b *positionalArg) string {
+	if a.variadic {
+		return a.field
+	}
+	return b.field
+}
@@ -1,7 +1,9 @@
 package cliz
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestPositionalArgsInvalidBool(t *testing.T) {
@@ -18,8 +20,9 @@ func TestPositionalArgsInvalidBool(t *testing.T) {
 	})
 
 	err := cmd.run([]string{"invalid"})
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
+	var valueErr *InvalidValueError
+	if !errors.As(err, &valueErr) || valueErr.Position != 0 || valueErr.Field != "Arg1" {
+		t.Fatalf("Expected InvalidValueError for Arg1, got %v", err)
 	}
 
 	if args.Arg1 != false {
@@ -54,8 +57,9 @@ func TestPositionalArgsInvalidInt64(t *testing.T) {
 	})
 
 	err := cmd.run([]string{"invalid"})
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
+	var valueErr *InvalidValueError
+	if !errors.As(err, &valueErr) || valueErr.Position != 0 || valueErr.Field != "Arg1" {
+		t.Fatalf("Expected InvalidValueError for Arg1, got %v", err)
 	}
 
 	if args.Arg1 != 0 {
@@ -77,8 +81,9 @@ func TestPositionalArgsInvalidUint(t *testing.T) {
 	})
 
 	err := cmd.run([]string{"invalid"})
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
+	var valueErr *InvalidValueError
+	if !errors.As(err, &valueErr) || valueErr.Position != 0 || valueErr.Field != "Arg1" {
+		t.Fatalf("Expected InvalidValueError for Arg1, got %v", err)
 	}
 
 	if args.Arg1 != 0 {
@@ -100,8 +105,9 @@ func TestPositionalArgsInvalidUint64(t *testing.T) {
 	})
 
 	err := cmd.run([]string{"invalid"})
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
+	var valueErr *InvalidValueError
+	if !errors.As(err, &valueErr) || valueErr.Position != 0 || valueErr.Field != "Arg1" {
+		t.Fatalf("Expected InvalidValueError for Arg1, got %v", err)
 	}
 
 	if args.Arg1 != 0 {
@@ -123,8 +129,9 @@ func TestPositionalArgsInvalidFloat32(t *testing.T) {
 	})
 
 	err := cmd.run([]string{"invalid"})
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
+	var valueErr *InvalidValueError
+	if !errors.As(err, &valueErr) || valueErr.Position != 0 || valueErr.Field != "Arg1" {
+		t.Fatalf("Expected InvalidValueError for Arg1, got %v", err)
 	}
 
 	if args.Arg1 != 0 {
@@ -146,8 +153,9 @@ func TestPositionalArgsInvalidFloat64(t *testing.T) {
 	})
 
 	err := cmd.run([]string{"invalid"})
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
+	var valueErr *InvalidValueError
+	if !errors.As(err, &valueErr) || valueErr.Position != 0 || valueErr.Field != "Arg1" {
+		t.Fatalf("Expected InvalidValueError for Arg1, got %v", err)
 	}
 
 	if args.Arg1 != 0 {
@@ -169,8 +177,9 @@ func TestPositionalArgsNegativeToUint(t *testing.T) {
 		return nil
 	})
 	err := cmd.parsePositionalArgs([]string{"-42"})
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
+	var valueErr *InvalidValueError
+	if !errors.As(err, &valueErr) || valueErr.Position != 0 || valueErr.Field != "Arg1" {
+		t.Fatalf("Expected InvalidValueError for Arg1, got %v", err)
 	}
 
 	if args.Arg1 != 0 {
@@ -231,3 +240,202 @@ func TestPositionalArgsEmptyPositionTag(t *testing.T) {
 		t.Fatalf("Expected arg2 'arg2', got '%s'", args.Arg2)
 	}
 }
+
+func TestPositionalArgsRegisteredTypes(t *testing.T) {
+	type Args struct {
+		Timeout time.Duration `position:"0"`
+		Count   int           `position:"1"`
+	}
+
+	args := Args{}
+	cmd := NewCommand("test", "test command")
+	cmd.AddPositionalArgs(&args)
+	cmd.Action(func() error {
+		return nil
+	})
+
+	err := cmd.run([]string{"1m30s", "16"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if args.Timeout != 90*time.Second || args.Count != 16 {
+		t.Fatalf("Expected 1m30s and 16, got %v and %d", args.Timeout, args.Count)
+	}
+}
+
+func TestPositionalArgsZeroPadded(t *testing.T) {
+	type Args struct {
+		Month int   `position:"0"`
+		Day   int   `position:"1"`
+		Count uint8 `position:"2"`
+	}
+
+	args := Args{}
+	cmd := NewCommand("test", "test command")
+	cmd.AddPositionalArgs(&args)
+	cmd.Action(func() error {
+		return nil
+	})
+
+	err := cmd.run([]string{"08", "010", "007"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if args.Month != 8 || args.Day != 10 || args.Count != 7 {
+		t.Fatalf("Expected 8, 10 and 7, got %d, %d and %d", args.Month, args.Day, args.Count)
+	}
+}
+
+func TestPositionalArgsValidateTags(t *testing.T) {
+	type Args struct {
+		Src   string `position:"0" validate:"required"`
+		Dst   string `position:"1" validate:"required"`
+		Count int    `position:"2" validate:"lt=10"`
+	}
+
+	args := Args{}
+	cli := NewCli("test", "test command", "1.0.0")
+	called := false
+	cli.NewSubCommand("copy", "copy files").AddPositionalArgs(&args).Action(func() error {
+		called = true
+		return nil
+	})
+
+	err := cli.Run("copy", "a.txt")
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 1 || validationErrs[0].Arg != "dst" {
+		t.Fatalf("Expected a validation error for Dst, got %v", err)
+	}
+	if called {
+		t.Fatalf("Expected action not to run")
+	}
+	if code := ExitCodeOf(err); code != ExitCodeValidation {
+		t.Fatalf("Expected exit code %d, got %d", ExitCodeValidation, code)
+	}
+
+	err = cli.Run("copy", "a.txt", "b.txt", "20")
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 1 || validationErrs[0].Arg != "count" {
+		t.Fatalf("Expected a validation error for Count, got %v", err)
+	}
+
+	err = cli.Run("copy", "a.txt", "b.txt", "5")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !called || args.Src != "a.txt" || args.Dst != "b.txt" || args.Count != 5 {
+		t.Fatalf("Unexpected args %+v", args)
+	}
+}
+
+func TestPositionalArgsInvalidRegistration(t *testing.T) {
+	tests := map[string]any{
+		"invalid position": &struct {
+			Arg string `position:"first"`
+		}{},
+		"duplicate position": &struct {
+			Arg1 string `position:"0"`
+			Arg2 string `position:"0"`
+		}{},
+		"unsupported type": &struct {
+			Arg struct{} `position:"0"`
+		}{},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Expected panic")
+				}
+			}()
+			NewCommand("test", "test command").AddPositionalArgs(args)
+		})
+	}
+}
+
+func TestPositionalArgsVariadic(t *testing.T) {
+	type Args struct {
+		Dst   string `position:"0"`
+		Srcs  []int  `position:"1..." default:"7,8"`
+		Label string
+	}
+
+	args := Args{}
+	cmd := NewCommand("test", "test command")
+	cmd.AddPositionalArgs(&args)
+	cmd.Action(func() error {
+		return nil
+	})
+
+	if len(args.Srcs) != 2 || args.Srcs[0] != 7 || args.Srcs[1] != 8 {
+		t.Fatalf("Expected default [7 8], got %v", args.Srcs)
+	}
+	err := cmd.run([]string{"out", "1", "2", "3"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if args.Dst != "out" || len(args.Srcs) != 3 || args.Srcs[0] != 1 || args.Srcs[2] != 3 {
+		t.Fatalf("Expected out and [1 2 3], got %s and %v", args.Dst, args.Srcs)
+	}
+
+	err = cmd.run([]string{"out", "4", "x"})
+	var valueErr *InvalidValueError
+	if !errors.As(err, &valueErr) || valueErr.Position != 2 || valueErr.Field != "Srcs" || valueErr.Type != "[]int" {
+		t.Fatalf("Expected InvalidValueError at position 2, got %v", err)
+	}
+}
+
+func TestPositionalArgsRest(t *testing.T) {
+	type Args struct {
+		Extra   []string `position:"rest"`
+		Command string   `position:"0" default:"help"`
+	}
+
+	args := Args{}
+	cmd := NewCommand("test", "test command")
+	cmd.AddPositionalArgs(&args)
+	cmd.Action(func() error {
+		return nil
+	})
+
+	err := cmd.run([]string{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if args.Command != "help" || len(args.Extra) != 0 {
+		t.Fatalf("Expected default command and no extra args, got %+v", args)
+	}
+
+	err = cmd.run([]string{"run", "--", "-v", "x"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if args.Command != "run" || len(args.Extra) != 2 || args.Extra[0] != "-v" || args.Extra[1] != "x" {
+		t.Fatalf("Expected run and [-v x], got %+v", args)
+	}
+}
+
+func TestPositionalArgsVariadicNotLast(t *testing.T) {
+	tests := map[string]any{
+		"variadic before fixed": &struct {
+			Srcs []string `position:"0..."`
+			Dst  string   `position:"1"`
+		}{},
+		"variadic scalar": &struct {
+			Src string `position:"0..."`
+		}{},
+		"two rest": &struct {
+			A []string `position:"rest"`
+			B []string `position:"rest"`
+		}{},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Expected panic")
+				}
+			}()
+			NewCommand("test", "test command").AddPositionalArgs(args)
+		})
+	}
+}
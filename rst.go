@@ -0,0 +1,126 @@
+package cliz
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GenerateReST writes a reStructuredText page for every visible command into
+// dir, named after the command path, e.g. myapp-server-start.rst.
+// Pages have the same content as the ones written by GenerateMarkdown.
+func (c *Cli) GenerateReST(dir string, options *DocOptions) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return c.rootCommand.walkVisible(func(cmd *Command) error {
+		path := filepath.Join(dir, cmd.docName()+".rst")
+		return os.WriteFile(path, []byte(cmd.restPage(options)), 0o644)
+	})
+}
+
+// restPage renders the reStructuredText page of the command.
+func (c *Command) restPage(options *DocOptions) string {
+	var b strings.Builder
+	restHeading(&b, c.commandPath, "=")
+	if c.shortdescription != "" {
+		fmt.Fprintf(&b, "%s\n\n", c.shortdescription)
+	}
+
+	restHeading(&b, "Synopsis", "-")
+	if c.longdescription != "" {
+		fmt.Fprintf(&b, "%s\n\n", strings.TrimSpace(c.longdescription))
+	}
+	fmt.Fprintf(&b, "::\n\n%s\n", restIndent(c.UseLine()))
+
+	if c.examples != "" {
+		restHeading(&b, "Examples", "-")
+		fmt.Fprintf(&b, "::\n\n%s\n", restIndent(strings.Trim(c.examples, "\n")))
+	}
+
+	if args := c.argumentDocs(); len(args) > 0 {
+		restHeading(&b, "Arguments", "-")
+		b.WriteString(".. list-table::\n   :header-rows: 1\n\n")
+		restRow(&b, "Argument", "Default", "Validation", "Description")
+		for _, arg := range args {
+			restRow(&b, "``"+arg.Usage+"``", restLiteral(arg.Default),
+				restLiteral(strings.Join(arg.Rules, ", ")), arg.Description)
+		}
+		b.WriteString("\n")
+	}
+
+	if flags := c.flagDocs(); len(flags) > 0 {
+		restHeading(&b, "Flags", "-")
+		b.WriteString(".. list-table::\n   :header-rows: 1\n\n")
+		restRow(&b, "Flag", "Type", "Default", "Environment", "Validation", "Description")
+		for _, f := range flags {
+			name := "``--" + f.Name + "``"
+			if f.Short != "" {
+				name = "``-" + f.Short + "``, " + name
+			}
+			env := make([]string, len(f.Env))
+			for i, v := range f.Env {
+				env[i] = "``" + v + "``"
+			}
+			restRow(&b, name, f.Type, restLiteral(f.Default), strings.Join(env, ", "),
+				restLiteral(strings.Join(f.Rules, ", ")), f.Description())
+		}
+		b.WriteString("\n")
+	}
+
+	if commands := c.visibleSubCommands(); len(commands) > 0 {
+		restHeading(&b, "Commands", "-")
+		for _, cmd := range commands {
+			fmt.Fprintf(&b, "* `%s <%s>`__ - %s\n", cmd.commandPath, options.link(cmd, ".rst"), cmd.shortdescription)
+		}
+		b.WriteString("\n")
+	}
+
+	if c.parent != nil {
+		restHeading(&b, "See also", "-")
+		fmt.Fprintf(&b, "* `%s <%s>`__ - %s\n", c.parent.commandPath, options.link(c.parent, ".rst"), c.parent.shortdescription)
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// restHeading writes a section title underlined with the given character.
+func restHeading(b *strings.Builder, title, underline string) {
+	fmt.Fprintf(b, "%s\n%s\n\n", title, strings.Repeat(underline, len(title)))
+}
+
+// restRow writes a row of a list-table.
+func restRow(b *strings.Builder, cells ...string) {
+	for i, cell := range cells {
+		prefix := "     -"
+		if i == 0 {
+			prefix = "   * -"
+		}
+		cell = strings.ReplaceAll(cell, "\n", " ")
+		if cell == "" {
+			fmt.Fprintf(b, "%s\n", prefix)
+		} else {
+			fmt.Fprintf(b, "%s %s\n", prefix, cell)
+		}
+	}
+}
+
+// restLiteral formats text as inline literal, or returns an empty string.
+func restLiteral(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "``" + s + "``"
+}
+
+// restIndent indents every line of text for a literal block.
+func restIndent(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "    " + line
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
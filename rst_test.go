@@ -0,0 +1,38 @@
+package cliz
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateReST(t *testing.T) {
+	cli := newDocsTestCli()
+	dir := t.TempDir()
+	err := cli.GenerateReST(dir, &DocOptions{
+		LinkHandler: func(filename string) string {
+			return strings.TrimSuffix(filename, ".rst") + ".html"
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "myapp-server.rst"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	page := string(data)
+	for _, expected := range []string{
+		"myapp server\n============\n\nRun the server\n",
+		"::\n\n    myapp server [flags] [command]\n",
+		"Examples\n--------\n\n::\n\n    myapp server --port 8080\n",
+		"   * - ``-p``, ``--port``\n     - int\n     -\n     - ``MYAPP_PORT``\n     - ``range=1-65535``\n     - Server port\n",
+		"* `myapp server start <myapp-server-start.html>`__ - Start the server",
+		"* `myapp <myapp.html>`__ - Manage things",
+	} {
+		if !strings.Contains(page, expected) {
+			t.Fatalf("Expected page to contain %q, got:\n%s", expected, page)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package cliz
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// HandleSignals enables cancelling the run context when the process receives
+// SIGINT or SIGTERM, so that ActionContext callbacks can shut down gracefully.
+// A second signal exits the process immediately with status 130.
+func (c *Cli) HandleSignals(enabled bool) *Cli {
+	c.handleSignals = enabled
+	return c
+}
+
+// notifySignals returns a copy of ctx that is cancelled on the first SIGINT or
+// SIGTERM. A second signal calls exit. The returned function stops listening
+// for signals and releases the context.
+func notifySignals(ctx context.Context, exit func(int)) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-done:
+			return
+		}
+		select {
+		case <-signals:
+			exit(ExitCodeInterrupted)
+		case <-done:
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(signals)
+		close(done)
+		cancel()
+	}
+}
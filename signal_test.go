@@ -0,0 +1,65 @@
+package cliz
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestActionContext(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var verbose bool
+	cli.Bool("verbose", "enable verbose", &verbose)
+	var gotArgs []string
+	var gotCmd *Command
+	cli.ActionContext(func(ctx context.Context, cmd *Command, args []string) error {
+		gotCmd = cmd
+		gotArgs = args
+		if cmd.Context() != ctx {
+			t.Fatalf("Expected Context to return the run context")
+		}
+		return nil
+	})
+	err := cli.Run("--verbose", "a", "b")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gotCmd != cli.RootCommand() {
+		t.Fatalf("Expected the root command to be passed to the action")
+	}
+	if len(gotArgs) != 2 || gotArgs[0] != "a" || gotArgs[1] != "b" {
+		t.Fatalf("Expected args [a b], got %v", gotArgs)
+	}
+}
+
+func TestRunContextCancelled(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	sub := cli.NewSubCommand("wait", "wait for cancellation")
+	sub.ActionContext(func(ctx context.Context, cmd *Command, args []string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := cli.RunContext(ctx, "wait")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded error, got %v", err)
+	}
+}
+
+func TestActionStillWorks(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	called := false
+	cli.Action(func() error {
+		called = true
+		return nil
+	})
+	err := cli.Run([]string{}...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !called {
+		t.Fatalf("Expected action to be called")
+	}
+}
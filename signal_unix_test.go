@@ -0,0 +1,44 @@
+//go:build unix
+
+package cliz
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestHandleSignals(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	exited := make(chan int, 1)
+	cli.exitFunction = func(code int) { exited <- code }
+	cli.HandleSignals(true)
+	cli.ActionContext(func(ctx context.Context, cmd *Command, args []string) error {
+		if err := syscall.Kill(syscall.Getpid(), syscall.SIGINT); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected context to be cancelled by SIGINT")
+		}
+		if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		select {
+		case code := <-exited:
+			if code != 130 {
+				t.Fatalf("Expected exit code 130, got %d", code)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected second signal to force exit")
+		}
+		return ctx.Err()
+	})
+	err := cli.Run([]string{}...)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context canceled error, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package cliz
+
+// FlagSource identifies where a flag value was set from.
+type FlagSource int
+
+const (
+	// SourceDefault means the flag was not set and holds its default value.
+	SourceDefault FlagSource = iota
+	// SourceConfig means the value was loaded from a configuration file.
+	SourceConfig
+	// SourceEnv means the value was read from an environment variable.
+	SourceEnv
+	// SourceArgv means the value was given on the command line.
+	SourceArgv
+)
+
+func (s FlagSource) String() string {
+	switch s {
+	case SourceConfig:
+		return "config"
+	case SourceEnv:
+		return "env"
+	case SourceArgv:
+		return "argv"
+	}
+	return "default"
+}
+
+// valueSource records where a flag value was loaded from.
+// The location is the environment variable name or config file path.
+type valueSource struct {
+	kind     FlagSource
+	location string
+}
+
+func (s valueSource) String() string {
+	switch s.kind {
+	case SourceConfig:
+		return "config file " + s.location
+	case SourceEnv:
+		return "environment variable " + s.location
+	case SourceArgv:
+		return "command line"
+	}
+	return "default"
+}
+
+// IsSet reports whether a flag was explicitly set on the command line,
+// through the environment or by a configuration file, as opposed to holding
+// its default value. It reflects the most recent parse of the command's arguments.
+func (c *Command) IsSet(flagName string) bool {
+	return c.flagSources[flagName].kind != SourceDefault
+}
+
+// FlagSource returns where the current value of a flag came from.
+// It reflects the most recent parse of the command's arguments.
+func (c *Command) FlagSource(flagName string) FlagSource {
+	return c.flagSources[flagName].kind
+}
+
+// ValueOrigin describes where the current value of a flag came from, for
+// example "command line", "environment variable APP_PORT",
+// "config file /etc/myapp.yaml" or "default".
+// It reflects the most recent parse of the command's arguments.
+func (c *Command) ValueOrigin(flagName string) string {
+	return c.flagSources[flagName].String()
+}
@@ -0,0 +1,86 @@
+package cliz
+
+import (
+	"testing"
+)
+
+func TestIsSet(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var count int
+	var name string
+	cli.Int("count", "set count", &count)
+	cli.String("name", "set name", &name)
+	err := cli.Run("--count=0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !cli.RootCommand().IsSet("count") {
+		t.Fatalf("Expected count to be set")
+	}
+	if cli.RootCommand().IsSet("name") {
+		t.Fatalf("Expected name not to be set")
+	}
+
+	err = cli.Run([]string{}...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cli.RootCommand().IsSet("count") {
+		t.Fatalf("Expected count not to be set after a new run")
+	}
+}
+
+func TestFlagSource(t *testing.T) {
+	t.Setenv("APP_NAME", "env-name")
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var count int
+	var name, mode string
+	cli.Int("count", "set count", &count)
+	cli.String("name", "set name", &name).Env("name", "APP_NAME")
+	cli.String("mode", "set mode", &mode)
+	err := cli.Run("-count", "3")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	root := cli.RootCommand()
+	if source := root.FlagSource("count"); source != SourceArgv {
+		t.Fatalf("Expected count source argv, got %v", source)
+	}
+	if source := root.FlagSource("name"); source != SourceEnv {
+		t.Fatalf("Expected name source env, got %v", source)
+	}
+	if source := root.FlagSource("mode"); source != SourceDefault {
+		t.Fatalf("Expected mode source default, got %v", source)
+	}
+	if SourceConfig.String() != "config" {
+		t.Fatalf("Expected 'config', got '%s'", SourceConfig.String())
+	}
+}
+
+func TestRequiredAcceptsExplicitZero(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	type config struct {
+		Retries int `name:"retries" description:"set retries" validate:"required"`
+	}
+	var cfg config
+	cli.AddFlags(&cfg)
+	err := cli.Run("--retries", "0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err = cli.Run([]string{}...)
+	if err == nil {
+		t.Fatalf("Expected required error when retries is not given, got nil")
+	}
+}
+
+func TestRequiredRejectsExplicitEmptyString(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var name string
+	cli.String("name", "set name", &name, Required())
+	err := cli.Run("--name=")
+	if err == nil {
+		t.Fatalf("Expected required error, got nil")
+	}
+}
@@ -0,0 +1,71 @@
+package cliz
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestionDistance is the largest edit distance at which a name is
+// offered as a suggestion.
+const maxSuggestionDistance = 2
+
+// suggest returns the candidates that are close to name, closest first.
+// A candidate is close if its edit distance to name is at most
+// maxSuggestionDistance or if it starts with name.
+func suggest(name string, candidates []string) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+	var matches []match
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if seen[candidate] || candidate == name {
+			continue
+		}
+		seen[candidate] = true
+		distance := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+		if distance <= maxSuggestionDistance || (name != "" && strings.HasPrefix(candidate, name)) {
+			matches = append(matches, match{name: candidate, distance: distance})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+	suggestions := make([]string, len(matches))
+	for i, m := range matches {
+		suggestions[i] = m.name
+	}
+	return suggestions
+}
+
+// editDistance returns the optimal string alignment distance between a and b:
+// the number of insertions, deletions, substitutions and transpositions of
+// adjacent characters needed to turn a into b.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
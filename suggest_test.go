@@ -0,0 +1,31 @@
+package cliz
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	if d := editDistance("server", "server"); d != 0 {
+		t.Fatalf("Expected distance 0, got %d", d)
+	}
+	if d := editDistance("serer", "server"); d != 1 {
+		t.Fatalf("Expected distance 1 for a deletion, got %d", d)
+	}
+	if d := editDistance("sevrer", "server"); d != 1 {
+		t.Fatalf("Expected distance 1 for a transposition, got %d", d)
+	}
+	if d := editDistance("", "abc"); d != 3 {
+		t.Fatalf("Expected distance 3, got %d", d)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	got := suggest("stat", []string{"status", "start", "stop", "deploy"})
+	if strings.Join(got, ",") != "start,status,stop" {
+		t.Fatalf("Expected suggestions start,status,stop, got %v", got)
+	}
+	if got := suggest("xyz", []string{"server", "deploy"}); len(got) != 0 {
+		t.Fatalf("Expected no suggestions, got %v", got)
+	}
+}
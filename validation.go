@@ -13,6 +13,19 @@ type Validator interface {
 	WithMessage(msg string) Validator
 }
 
+// setAwareValidator is implemented by validators that take into account
+// whether a flag was explicitly set, see validator.SetAwareValidator.
+type setAwareValidator interface {
+	ValidateSet(value any, set bool) error
+}
+
+// crossFieldValidator is implemented by validators that compare a flag with
+// other flags of the same struct, see validator.CrossFieldValidator.
+type crossFieldValidator interface {
+	RelatedField() string
+	ValidateFields(value any, set bool, fields validator.FieldLookup) error
+}
+
 // ValidatorFunc is a function type that implements the Validator interface
 type ValidatorFunc func(value any) error
 
@@ -42,10 +55,15 @@ func (e *ValidatorError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
-// parseValidateTags parses validate tags and creates corresponding validators
+// parseValidateTags parses validate tags and creates corresponding validators.
+// It panics on tags that name no registered validator or have an invalid parameter,
+// see validator.Register.
 func parseValidateTags(validateTags, fieldName string) []Validator {
 	// Delegate to validation package
-	externalValidators := validator.ValidateTags(validateTags, fieldName)
+	externalValidators, err := validator.ParseTags(validateTags, fieldName)
+	if err != nil {
+		panic(err.Error())
+	}
 	var validators []Validator
 	for _, v := range externalValidators {
 		validators = append(validators, validatorWrapper{v})
@@ -62,50 +80,47 @@ func (w validatorWrapper) Validate(value any) error {
 	return w.externalValidator.Validate(value)
 }
 
+// ValidateSet delegates to the wrapped validator if it takes into account
+// whether the value was set, and to Validate otherwise.
+func (w validatorWrapper) ValidateSet(value any, set bool) error {
+	if v, ok := w.externalValidator.(validator.SetAwareValidator); ok {
+		return v.ValidateSet(value, set)
+	}
+	return w.externalValidator.Validate(value)
+}
+
+// RelatedField returns the struct field name the wrapped validator refers to,
+// or an empty string if it does not compare with another field.
+func (w validatorWrapper) RelatedField() string {
+	if v, ok := w.externalValidator.(validator.CrossFieldValidator); ok {
+		return v.RelatedField()
+	}
+	return ""
+}
+
+// ValidateFields delegates to the wrapped validator if it compares with other fields,
+// and to ValidateSet otherwise.
+func (w validatorWrapper) ValidateFields(value any, set bool, fields validator.FieldLookup) error {
+	if v, ok := w.externalValidator.(validator.CrossFieldValidator); ok {
+		return v.ValidateFields(value, set, fields)
+	}
+	return w.ValidateSet(value, set)
+}
+
+// String describes the wrapped validator's rule in validate tag form,
+// or returns an empty string if the rule cannot be described.
+func (w validatorWrapper) String() string {
+	if s, ok := w.externalValidator.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return ""
+}
+
 func (w validatorWrapper) WithMessage(msg string) Validator {
-	switch v := w.externalValidator.(type) {
-	case *validator.RangeValidator:
-		v.ErrorMessage = msg
-		return w
-	case *validator.RequiredValidator:
-		v.ErrorMessage = msg
-		return w
-	case *validator.LenValidator:
-		v.ErrorMessage = msg
-		return w
-	case *validator.PatternValidator:
-		v.ErrorMessage = msg
-		return w
-	case *validator.InValidator:
-		v.ErrorMessage = msg
-		return w
-	case *validator.EqValidator:
-		v.ErrorMessage = msg
-		return w
-	case *validator.GtValidator:
-		v.ErrorMessage = msg
-		return w
-	case *validator.LtValidator:
-		v.ErrorMessage = msg
-		return w
-	case *validator.ContainsValidator:
-		v.ErrorMessage = msg
-		return w
-	case *validator.EmailValidator:
-		v.ErrorMessage = msg
-		return w
-	case *validator.URLValidator:
-		v.ErrorMessage = msg
-		return w
-	case *validator.AlphaValidator:
-		v.ErrorMessage = msg
-		return w
-	case *validator.AlphanumValidator:
-		v.ErrorMessage = msg
-		return w
-	default:
-		return w
+	if v, ok := w.externalValidator.(validator.MessageSetter); ok {
+		v.SetErrorMessage(msg)
 	}
+	return w
 }
 
 // Range creates a validator that checks if a value is within the specified range
@@ -10,6 +10,16 @@ type AlphaValidator struct {
 
 var alphaRegex = regexp.MustCompile(`^[a-zA-Z]+$`)
 
+// String returns the rule in validate tag form.
+func (v *AlphaValidator) String() string {
+	return "alpha"
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *AlphaValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
 func (v *AlphaValidator) Validate(value any) error {
 	errMsg := getErrorMessage(defaultAlphaMsg, v.ErrorMessage)
 	switch val := value.(type) {
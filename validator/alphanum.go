@@ -10,6 +10,16 @@ type AlphanumValidator struct {
 
 var alphanumRegex = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
 
+// String returns the rule in validate tag form.
+func (v *AlphanumValidator) String() string {
+	return "alphanum"
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *AlphanumValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
 func (v *AlphanumValidator) Validate(value any) error {
 	errMsg := getErrorMessage(defaultAlphanumMsg, v.ErrorMessage)
 	switch val := value.(type) {
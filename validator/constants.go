@@ -14,6 +14,13 @@ const (
 	defaultURLMsg      = "must be a valid URL"
 	defaultAlphaMsg    = "must contain only alphabetic characters"
 	defaultAlphanumMsg = "must contain only alphanumeric characters"
+
+	defaultRequiredIfMsg     = "is required when %v is %v"
+	defaultRequiredUnlessMsg = "is required unless %v is %v"
+	defaultRequiredWithMsg   = "is required when %v is set"
+	defaultExcludedWithMsg   = "cannot be used with %v"
+	defaultGtFieldMsg        = "must be greater than %v"
+	defaultEqFieldMsg        = "must equal %v"
 )
 
 const (
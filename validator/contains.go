@@ -10,6 +10,16 @@ type ContainsValidator struct {
 	ErrorMessage string
 }
 
+// String returns the rule in validate tag form.
+func (v *ContainsValidator) String() string {
+	return "contains=" + v.Substring
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *ContainsValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
 func (v *ContainsValidator) Validate(value any) error {
 	errMsg := getErrorMessage(defaultContainsMsg, v.ErrorMessage)
 	switch val := value.(type) {
@@ -0,0 +1,278 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RequiredIfValidator requires a value when another field equals the given value,
+// e.g. required_if=Mode prod.
+type RequiredIfValidator struct {
+	FieldName    string
+	Other        string
+	Value        string
+	ErrorMessage string
+}
+
+// String returns the rule in validate tag form.
+func (v *RequiredIfValidator) String() string {
+	return fmt.Sprintf("required_if=%s %s", v.Other, v.Value)
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *RequiredIfValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
+// RelatedField returns the struct field name the rule refers to.
+func (v *RequiredIfValidator) RelatedField() string {
+	return v.Other
+}
+
+func (v *RequiredIfValidator) Validate(value any) error {
+	return v.ValidateFields(value, true, nil)
+}
+
+func (v *RequiredIfValidator) ValidateSet(value any, set bool) error {
+	return v.ValidateFields(value, set, nil)
+}
+
+func (v *RequiredIfValidator) ValidateFields(value any, set bool, fields FieldLookup) error {
+	other, ok := lookupField(fields, v.Other)
+	if !ok || fmt.Sprint(other.Value) != v.Value || !missing(value, set) {
+		return nil
+	}
+	return createValidatorError(v.FieldName, getErrorMessage(defaultRequiredIfMsg, v.ErrorMessage), other.Name, v.Value)
+}
+
+// RequiredUnlessValidator requires a value unless another field equals the given value,
+// e.g. required_unless=Mode dev.
+type RequiredUnlessValidator struct {
+	FieldName    string
+	Other        string
+	Value        string
+	ErrorMessage string
+}
+
+// String returns the rule in validate tag form.
+func (v *RequiredUnlessValidator) String() string {
+	return fmt.Sprintf("required_unless=%s %s", v.Other, v.Value)
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *RequiredUnlessValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
+// RelatedField returns the struct field name the rule refers to.
+func (v *RequiredUnlessValidator) RelatedField() string {
+	return v.Other
+}
+
+func (v *RequiredUnlessValidator) Validate(value any) error {
+	return v.ValidateFields(value, true, nil)
+}
+
+func (v *RequiredUnlessValidator) ValidateSet(value any, set bool) error {
+	return v.ValidateFields(value, set, nil)
+}
+
+func (v *RequiredUnlessValidator) ValidateFields(value any, set bool, fields FieldLookup) error {
+	other, ok := lookupField(fields, v.Other)
+	if !ok || fmt.Sprint(other.Value) == v.Value || !missing(value, set) {
+		return nil
+	}
+	return createValidatorError(v.FieldName, getErrorMessage(defaultRequiredUnlessMsg, v.ErrorMessage), other.Name, v.Value)
+}
+
+// RequiredWithValidator requires a value when another field is set,
+// e.g. required_with=Cert.
+type RequiredWithValidator struct {
+	FieldName    string
+	Other        string
+	ErrorMessage string
+}
+
+// String returns the rule in validate tag form.
+func (v *RequiredWithValidator) String() string {
+	return "required_with=" + v.Other
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *RequiredWithValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
+// RelatedField returns the struct field name the rule refers to.
+func (v *RequiredWithValidator) RelatedField() string {
+	return v.Other
+}
+
+func (v *RequiredWithValidator) Validate(value any) error {
+	return v.ValidateFields(value, true, nil)
+}
+
+func (v *RequiredWithValidator) ValidateSet(value any, set bool) error {
+	return v.ValidateFields(value, set, nil)
+}
+
+func (v *RequiredWithValidator) ValidateFields(value any, set bool, fields FieldLookup) error {
+	other, ok := lookupField(fields, v.Other)
+	if !ok || !other.Set || !missing(value, set) {
+		return nil
+	}
+	return createValidatorError(v.FieldName, getErrorMessage(defaultRequiredWithMsg, v.ErrorMessage), other.Name)
+}
+
+// ExcludedWithValidator rejects a value that is set together with another field,
+// e.g. excluded_with=Token.
+type ExcludedWithValidator struct {
+	FieldName    string
+	Other        string
+	ErrorMessage string
+}
+
+// String returns the rule in validate tag form.
+func (v *ExcludedWithValidator) String() string {
+	return "excluded_with=" + v.Other
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *ExcludedWithValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
+// RelatedField returns the struct field name the rule refers to.
+func (v *ExcludedWithValidator) RelatedField() string {
+	return v.Other
+}
+
+func (v *ExcludedWithValidator) Validate(value any) error {
+	return v.ValidateFields(value, true, nil)
+}
+
+func (v *ExcludedWithValidator) ValidateSet(value any, set bool) error {
+	return v.ValidateFields(value, set, nil)
+}
+
+func (v *ExcludedWithValidator) ValidateFields(value any, set bool, fields FieldLookup) error {
+	other, ok := lookupField(fields, v.Other)
+	if !ok || !set || !other.Set {
+		return nil
+	}
+	return createValidatorError(v.FieldName, getErrorMessage(defaultExcludedWithMsg, v.ErrorMessage), other.Name)
+}
+
+// GtFieldValidator requires a numeric value to be greater than another numeric field,
+// e.g. gtfield=MinPort. A zero value that was not set is not checked.
+type GtFieldValidator struct {
+	FieldName    string
+	Other        string
+	ErrorMessage string
+}
+
+// String returns the rule in validate tag form.
+func (v *GtFieldValidator) String() string {
+	return "gtfield=" + v.Other
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *GtFieldValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
+// RelatedField returns the struct field name the rule refers to.
+func (v *GtFieldValidator) RelatedField() string {
+	return v.Other
+}
+
+func (v *GtFieldValidator) Validate(value any) error {
+	return v.ValidateFields(value, true, nil)
+}
+
+func (v *GtFieldValidator) ValidateSet(value any, set bool) error {
+	return v.ValidateFields(value, set, nil)
+}
+
+func (v *GtFieldValidator) ValidateFields(value any, set bool, fields FieldLookup) error {
+	other, ok := lookupField(fields, v.Other)
+	if !ok {
+		return nil
+	}
+	val, ok := asNumber(value)
+	if !ok || (!set && val == 0) {
+		return nil
+	}
+	otherVal, ok := asNumber(other.Value)
+	if !ok || val > otherVal {
+		return nil
+	}
+	return createValidatorError(v.FieldName, getErrorMessage(defaultGtFieldMsg, v.ErrorMessage), other.Name)
+}
+
+// EqFieldValidator requires a value to equal another field,
+// e.g. eqfield=Password.
+type EqFieldValidator struct {
+	FieldName    string
+	Other        string
+	ErrorMessage string
+}
+
+// String returns the rule in validate tag form.
+func (v *EqFieldValidator) String() string {
+	return "eqfield=" + v.Other
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *EqFieldValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
+// RelatedField returns the struct field name the rule refers to.
+func (v *EqFieldValidator) RelatedField() string {
+	return v.Other
+}
+
+func (v *EqFieldValidator) Validate(value any) error {
+	return v.ValidateFields(value, true, nil)
+}
+
+func (v *EqFieldValidator) ValidateSet(value any, set bool) error {
+	return v.ValidateFields(value, set, nil)
+}
+
+func (v *EqFieldValidator) ValidateFields(value any, set bool, fields FieldLookup) error {
+	other, ok := lookupField(fields, v.Other)
+	if !ok || reflect.DeepEqual(value, other.Value) {
+		return nil
+	}
+	return createValidatorError(v.FieldName, getErrorMessage(defaultEqFieldMsg, v.ErrorMessage), other.Name)
+}
+
+// lookupField resolves a sibling field, reporting false when there are no fields to look in.
+func lookupField(fields FieldLookup, name string) (Field, bool) {
+	if fields == nil {
+		return Field{}, false
+	}
+	return fields(name)
+}
+
+// missing reports whether a value counts as not provided, see RequiredValidator.ValidateSet.
+func missing(value any, set bool) bool {
+	return (&RequiredValidator{}).ValidateSet(value, set) != nil
+}
+
+// asNumber converts any integer or float kind, including named types
+// such as time.Duration, to a float64.
+func asNumber(value any) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+func testFields(fields ...Field) FieldLookup {
+	return func(name string) (Field, bool) {
+		for _, f := range fields {
+			if f.Name == name {
+				return f, true
+			}
+		}
+		return Field{}, false
+	}
+}
+
+func crossFieldValidator(t *testing.T, tag string) CrossFieldValidator {
+	t.Helper()
+	validators := ValidateTags(tag, "field")
+	if len(validators) != 1 {
+		t.Fatalf("Expected 1 validator, got %d", len(validators))
+	}
+	v, ok := validators[0].(CrossFieldValidator)
+	if !ok {
+		t.Fatalf("Expected a CrossFieldValidator, got %T", validators[0])
+	}
+	return v
+}
+
+func TestRequiredIfValidator(t *testing.T) {
+	v := crossFieldValidator(t, "required_if=Mode prod")
+	if v.RelatedField() != "Mode" || v.(*RequiredIfValidator).String() != "required_if=Mode prod" {
+		t.Fatalf("Unexpected validator: %v", v)
+	}
+	if err := v.ValidateFields("", false, testFields(Field{Name: "Mode", Value: "dev"})); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	err := v.ValidateFields("", false, testFields(Field{Name: "Mode", Value: "prod"}))
+	if err == nil || err.Error() != "field: is required when Mode is prod" {
+		t.Fatalf("Expected required_if error, got %v", err)
+	}
+	if err := v.ValidateFields("a.pem", true, testFields(Field{Name: "Mode", Value: "prod"})); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := v.Validate(""); err != nil {
+		t.Fatalf("Expected no error without fields, got %v", err)
+	}
+}
+
+func TestRequiredUnlessValidator(t *testing.T) {
+	v := crossFieldValidator(t, "required_unless=Mode dev")
+	if err := v.ValidateFields(0, false, testFields(Field{Name: "Mode", Value: "dev"})); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	err := v.ValidateFields(0, false, testFields(Field{Name: "Mode", Value: "prod"}))
+	if err == nil || err.Error() != "field: is required unless Mode is dev" {
+		t.Fatalf("Expected required_unless error, got %v", err)
+	}
+}
+
+func TestRequiredWithAndExcludedWithValidators(t *testing.T) {
+	requiredWith := crossFieldValidator(t, "required_with=Cert")
+	excludedWith := crossFieldValidator(t, "excluded_with=Cert")
+	unset := testFields(Field{Name: "Cert", Value: "a.pem"})
+	set := testFields(Field{Name: "Cert", Value: "a.pem", Set: true})
+
+	if err := requiredWith.ValidateFields("", false, unset); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	err := requiredWith.ValidateFields("", false, set)
+	if err == nil || err.Error() != "field: is required when Cert is set" {
+		t.Fatalf("Expected required_with error, got %v", err)
+	}
+
+	if err := excludedWith.ValidateFields("x", true, unset); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	err = excludedWith.ValidateFields("x", true, set)
+	if err == nil || err.Error() != "field: cannot be used with Cert" {
+		t.Fatalf("Expected excluded_with error, got %v", err)
+	}
+}
+
+func TestGtFieldValidator(t *testing.T) {
+	v := crossFieldValidator(t, "gtfield=MinPort")
+	fields := testFields(Field{Name: "MinPort", Value: 1000})
+	if err := v.ValidateFields(2000, true, fields); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := v.ValidateFields(0, false, fields); err != nil {
+		t.Fatalf("Expected unset zero value to be skipped, got %v", err)
+	}
+	err := v.ValidateFields(80, true, fields)
+	if err == nil || err.Error() != "field: must be greater than MinPort" {
+		t.Fatalf("Expected gtfield error, got %v", err)
+	}
+
+	durations := testFields(Field{Name: "MinPort", Value: time.Second})
+	if err := v.ValidateFields(time.Millisecond, true, durations); err == nil {
+		t.Fatal("Expected gtfield error for durations")
+	}
+}
+
+func TestEqFieldValidator(t *testing.T) {
+	v := crossFieldValidator(t, "eqfield=Password,error_eqfield=passwords do not match")
+	fields := testFields(Field{Name: "Password", Value: "secret"})
+	if err := v.ValidateFields("secret", true, fields); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	err := v.ValidateFields("other", true, fields)
+	if err == nil || err.Error() != "field: passwords do not match" {
+		t.Fatalf("Expected eqfield error, got %v", err)
+	}
+}
@@ -13,6 +13,16 @@ type EmailValidator struct {
 	ErrorMessage string
 }
 
+// String returns the rule in validate tag form.
+func (v *EmailValidator) String() string {
+	return "email"
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *EmailValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
 func (v *EmailValidator) Validate(value any) error {
 	errMsg := getErrorMessage(defaultEmailMsg, v.ErrorMessage)
 	switch val := value.(type) {
@@ -8,6 +8,16 @@ type EqValidator struct {
 	ErrorMessage string
 }
 
+// String returns the rule in validate tag form.
+func (v *EqValidator) String() string {
+	return fmt.Sprintf("eq=%v", v.Value)
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *EqValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
 func (v *EqValidator) Validate(value any) error {
 	errMsg := getErrorMessage(defaultEqMsg, v.ErrorMessage)
 	switch val := value.(type) {
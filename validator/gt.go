@@ -1,11 +1,23 @@
 package validator
 
+import "fmt"
+
 type GtValidator struct {
 	FieldName    string
 	Value        float64
 	ErrorMessage string
 }
 
+// String returns the rule in validate tag form.
+func (v *GtValidator) String() string {
+	return fmt.Sprintf("gt=%g", v.Value)
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *GtValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
 func (v *GtValidator) Validate(value any) error {
 	errMsg := getErrorMessage(defaultGtMsg, v.ErrorMessage)
 
@@ -1,6 +1,9 @@
 package validator
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type InValidator struct {
 	FieldName    string
@@ -8,6 +11,16 @@ type InValidator struct {
 	ErrorMessage string
 }
 
+// String returns the rule in validate tag form.
+func (v *InValidator) String() string {
+	return "in=" + strings.Join(v.Allowed, "|")
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *InValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
 func (v *InValidator) Validate(value any) error {
 	inTemplate := ""
 	for k := range v.Allowed {
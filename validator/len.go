@@ -8,6 +8,16 @@ type LenValidator struct {
 	ErrorMessage string
 }
 
+// String returns the rule in validate tag form.
+func (v *LenValidator) String() string {
+	return fmt.Sprintf("len=%d", v.Length)
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *LenValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
 func (v *LenValidator) Validate(value any) error {
 	errMsg := getErrorMessage(defaultLenMsg, v.ErrorMessage)
 
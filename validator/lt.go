@@ -8,6 +8,16 @@ type LtValidator struct {
 	ErrorMessage string
 }
 
+// String returns the rule in validate tag form.
+func (v *LtValidator) String() string {
+	return fmt.Sprintf("lt=%g", v.Value)
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *LtValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
 func (v *LtValidator) Validate(value any) error {
 	errMsg := getErrorMessage(defaultLtMsg, v.ErrorMessage)
 
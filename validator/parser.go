@@ -1,32 +1,23 @@
 package validator
 
 import (
-	"regexp"
-	"strconv"
+	"fmt"
 	"strings"
 )
 
 // parseValidateTags parses validate tags and creates corresponding validators
-func parseValidateTags(validateTags, fieldName string) []Validator {
+// through the registered factories. Tags that cannot be parsed are skipped
+// and reported as *TagError.
+func parseValidateTags(validateTags, fieldName string) ([]Validator, []error) {
 	var validators []Validator
+	var errs []error
 	var errorMap = make(map[string]string)
+	var failed = make(map[string]bool)
 
 	// First pass: process all error tags to populate errorMap
-	tags := strings.Split(validateTags, ",")
+	tags := splitTags(validateTags)
 	for _, tag := range tags {
-		tag = strings.TrimSpace(tag)
-		if tag == "" {
-			continue
-		}
-
-		parts := strings.SplitN(tag, "=", 2)
-		tagName := strings.TrimSpace(parts[0])
-		var tagValue string
-		if len(parts) > 1 {
-			tagValue = strings.TrimSpace(parts[1])
-			tagValue = strings.Trim(tagValue, `"'`)
-		}
-
+		tagName, tagValue := splitTag(tag)
 		if strings.HasPrefix(tagName, "error_") {
 			// Extract validator name from error tag
 			validatorName := tagName[len("error_"):]
@@ -36,174 +27,106 @@ func parseValidateTags(validateTags, fieldName string) []Validator {
 
 	// Second pass: process validator tags
 	for _, tag := range tags {
-		tag = strings.TrimSpace(tag)
-		if tag == "" {
+		tagName, tagValue := splitTag(tag)
+		if tagName == "" || strings.HasPrefix(tagName, "error_") {
 			continue
 		}
 
-		parts := strings.SplitN(tag, "=", 2)
-		tagName := strings.TrimSpace(parts[0])
-		var tagValue string
-		if len(parts) > 1 {
-			tagValue = strings.TrimSpace(parts[1])
-			tagValue = strings.Trim(tagValue, `"'`)
+		factory, ok := lookupFactory(tagName)
+		if !ok {
+			errs = append(errs, &TagError{Field: fieldName, Tag: strings.TrimSpace(tag), Err: ErrUnknownTag})
+			failed[tagName] = true
+			continue
 		}
-
-		if strings.HasPrefix(tagName, "error_") {
+		v, err := factory(tagValue, fieldName)
+		if err != nil {
+			errs = append(errs, &TagError{Field: fieldName, Tag: strings.TrimSpace(tag), Err: err})
+			failed[tagName] = true
 			continue
 		}
 
-		switch tagName {
-		case "required":
-			var errMsg string
-			if val, ok := errorMap["required"]; ok {
-				errMsg = val
-				delete(errorMap, "required")
-			}
-			validators = append(validators, &RequiredValidator{FieldName: fieldName, ErrorMessage: errMsg})
-		case "range":
-			if tagValue != "" {
-				parts := strings.Split(tagValue, "-")
-				if len(parts) == 2 {
-					min, err1 := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
-					max, err2 := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-					if err1 == nil && err2 == nil {
-						var errMsg string
-						if val, ok := errorMap["range"]; ok {
-							errMsg = val
-							delete(errorMap, "range")
-						}
-						validators = append(validators, &RangeValidator{FieldName: fieldName, Min: min, Max: max, ErrorMessage: errMsg})
-					}
-				}
-			}
-		case "len":
-			if tagValue != "" {
-				length, err := strconv.Atoi(tagValue)
-				if err == nil {
-					var errMsg string
-					if val, ok := errorMap["len"]; ok {
-						errMsg = val
-						delete(errorMap, "len")
-					}
-					validators = append(validators, &LenValidator{FieldName: fieldName, Length: length, ErrorMessage: errMsg})
-				}
-			}
-		case "pattern":
-			if tagValue != "" {
-				re, err := regexp.Compile(tagValue)
-				if err == nil {
-					var errMsg string
-					if val, ok := errorMap["pattern"]; ok {
-						errMsg = val
-						delete(errorMap, "pattern")
-					}
-					validators = append(validators, &PatternValidator{FieldName: fieldName, Pattern: tagValue, Regexp: re, ErrorMessage: errMsg})
-				}
-			}
-		case "in":
-			if tagValue != "" {
-				allowed := strings.Split(tagValue, "|")
-				var errMsg string
-				if val, ok := errorMap["in"]; ok {
-					errMsg = val
-					delete(errorMap, "in")
-				}
-				validators = append(validators, &InValidator{FieldName: fieldName, Allowed: allowed, ErrorMessage: errMsg})
-			}
-		case "eq":
-			if tagValue != "" {
-				if valInt, err := strconv.Atoi(tagValue); err == nil {
-					var errMsg string
-					if val, ok := errorMap["eq"]; ok {
-						errMsg = val
-						delete(errorMap, "eq")
-					}
-					validators = append(validators, &EqValidator{FieldName: fieldName, Value: valInt, ErrorMessage: errMsg})
-				} else if valFloat, err := strconv.ParseFloat(tagValue, 64); err == nil {
-					var errMsg string
-					if val, ok := errorMap["eq"]; ok {
-						errMsg = val
-						delete(errorMap, "eq")
-					}
-					validators = append(validators, &EqValidator{FieldName: fieldName, Value: valFloat, ErrorMessage: errMsg})
-				} else if tagValue == "true" || tagValue == "false" {
-					valBool, _ := strconv.ParseBool(tagValue)
-					var errMsg string
-					if val, ok := errorMap["eq"]; ok {
-						errMsg = val
-						delete(errorMap, "eq")
-					}
-					validators = append(validators, &EqValidator{FieldName: fieldName, Value: valBool, ErrorMessage: errMsg})
-				} else {
-					var errMsg string
-					if val, ok := errorMap["eq"]; ok {
-						errMsg = val
-						delete(errorMap, "eq")
-					}
-					validators = append(validators, &EqValidator{FieldName: fieldName, Value: tagValue, ErrorMessage: errMsg})
-				}
-			}
-		case "gt":
-			if tagValue != "" {
-				if valFloat, err := strconv.ParseFloat(tagValue, 64); err == nil {
-					var errMsg string
-					if val, ok := errorMap["gt"]; ok {
-						errMsg = val
-						delete(errorMap, "gt")
-					}
-					validators = append(validators, &GtValidator{FieldName: fieldName, Value: valFloat, ErrorMessage: errMsg})
-				}
-			}
-		case "lt":
-			if tagValue != "" {
-				if valFloat, err := strconv.ParseFloat(tagValue, 64); err == nil {
-					var errMsg string
-					if val, ok := errorMap["lt"]; ok {
-						errMsg = val
-						delete(errorMap, "lt")
-					}
-					validators = append(validators, &LtValidator{FieldName: fieldName, Value: valFloat, ErrorMessage: errMsg})
-				}
-			}
-		case "contains":
-			if tagValue != "" {
-				var errMsg string
-				if val, ok := errorMap["contains"]; ok {
-					errMsg = val
-					delete(errorMap, "contains")
-				}
-				validators = append(validators, &ContainsValidator{FieldName: fieldName, Substring: tagValue, ErrorMessage: errMsg})
+		if msg, ok := errorMap[tagName]; ok {
+			if setter, ok := v.(MessageSetter); ok {
+				setter.SetErrorMessage(msg)
+				delete(errorMap, tagName)
 			}
-		case "email":
-			var errMsg string
-			if val, ok := errorMap["email"]; ok {
-				errMsg = val
-				delete(errorMap, "email")
-			}
-			validators = append(validators, &EmailValidator{FieldName: fieldName, ErrorMessage: errMsg})
-		case "url":
-			var errMsg string
-			if val, ok := errorMap["url"]; ok {
-				errMsg = val
-				delete(errorMap, "url")
-			}
-			validators = append(validators, &URLValidator{FieldName: fieldName, ErrorMessage: errMsg})
-		case "alpha":
-			var errMsg string
-			if val, ok := errorMap["alpha"]; ok {
-				errMsg = val
-				delete(errorMap, "alpha")
+		}
+		validators = append(validators, v)
+	}
+
+	// Messages left over have no tag they could apply to, unless the tag itself failed
+	for _, tag := range tags {
+		tagName, _ := splitTag(tag)
+		if name, ok := strings.CutPrefix(tagName, "error_"); ok {
+			if _, ok := errorMap[name]; ok && !failed[name] {
+				errs = append(errs, &TagError{Field: fieldName, Tag: strings.TrimSpace(tag), Err: fmt.Errorf("no '%s' validator accepting a message", name)})
+				delete(errorMap, name)
 			}
-			validators = append(validators, &AlphaValidator{FieldName: fieldName, ErrorMessage: errMsg})
-		case "alphanum":
-			var errMsg string
-			if val, ok := errorMap["alphanum"]; ok {
-				errMsg = val
-				delete(errorMap, "alphanum")
+		}
+	}
+	return validators, errs
+}
+
+// splitTags splits validate tags into single tags. A comma starts a new tag only
+// if the text after it starts with a registered tag name or error_, so values such
+// as pattern=^a{2,}$ or error messages containing commas need no escaping.
+// A comma is always kept in the value when it is escaped as \, or inside a value
+// quoted with single or double quotes, e.g. contains='a,email' or error_eq="a, or email".
+func splitTags(validateTags string) []string {
+	var tags []string
+	var current strings.Builder
+	valueStart := false // Whether the next character starts the value of a tag
+	flush := func() {
+		tag := current.String()
+		current.Reset()
+		if strings.TrimSpace(tag) == "" {
+			return
+		}
+		name, _, _ := strings.Cut(tag, "=")
+		name = strings.TrimSpace(name)
+		_, registered := lookupFactory(name)
+		if len(tags) > 0 && strings.Contains(tags[len(tags)-1], "=") && !registered && !strings.HasPrefix(name, "error_") {
+			tags[len(tags)-1] += "," + tag
+			return
+		}
+		tags = append(tags, tag)
+	}
+	for i := 0; i < len(validateTags); i++ {
+		ch := validateTags[i]
+		startsValue := valueStart
+		valueStart = false
+		switch {
+		case ch == '\\' && i+1 < len(validateTags) && validateTags[i+1] == ',':
+			current.WriteByte(',')
+			i++
+		case startsValue && (ch == '"' || ch == '\''):
+			// Copy the quoted value, quotes included, up to the closing quote
+			end := strings.IndexByte(validateTags[i+1:], ch)
+			if end < 0 {
+				current.WriteString(validateTags[i:])
+				i = len(validateTags)
+				break
 			}
-			validators = append(validators, &AlphanumValidator{FieldName: fieldName, ErrorMessage: errMsg})
+			current.WriteString(validateTags[i : i+end+2])
+			i += end + 1
+		case ch == '=' && !strings.Contains(current.String(), "="):
+			current.WriteByte(ch)
+			valueStart = true
+		case ch == ' ' && startsValue:
+			current.WriteByte(ch)
+			valueStart = true
+		case ch == ',':
+			flush()
+		default:
+			current.WriteByte(ch)
 		}
 	}
-	return validators
+	flush()
+	return tags
+}
+
+// splitTag splits a single validate tag into its name and its unquoted parameter.
+func splitTag(tag string) (string, string) {
+	name, value, _ := strings.Cut(strings.TrimSpace(tag), "=")
+	return strings.TrimSpace(name), strings.Trim(strings.TrimSpace(value), `"'`)
 }
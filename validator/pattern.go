@@ -11,6 +11,16 @@ type PatternValidator struct {
 	*regexp.Regexp
 }
 
+// String returns the rule in validate tag form.
+func (v *PatternValidator) String() string {
+	return "pattern=" + v.Pattern
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *PatternValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
 func (v *PatternValidator) Validate(value any) error {
 	errMsg := getErrorMessage(defaultPatternMsg, v.ErrorMessage)
 
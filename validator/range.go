@@ -1,5 +1,7 @@
 package validator
 
+import "fmt"
+
 type RangeValidator struct {
 	FieldName    string
 	Min          float64
@@ -7,6 +9,16 @@ type RangeValidator struct {
 	ErrorMessage string
 }
 
+// String returns the rule in validate tag form.
+func (r *RangeValidator) String() string {
+	return fmt.Sprintf("range=%g-%g", r.Min, r.Max)
+}
+
+// SetErrorMessage replaces the default error message.
+func (r *RangeValidator) SetErrorMessage(msg string) {
+	r.ErrorMessage = msg
+}
+
 func (r *RangeValidator) Validate(value any) error {
 	errMsg := getErrorMessage(defaultRangeMsg, r.ErrorMessage)
 
@@ -0,0 +1,203 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Factory creates the validator for a validate tag.
+// param is the text after '=' in the tag, or empty if there is none,
+// and field is the name of the field being validated.
+// It returns an error if param is not valid for the validator.
+type Factory func(param string, field string) (Validator, error)
+
+// ErrUnknownTag is wrapped by the TagError of a tag with no registered validator.
+var ErrUnknownTag = errors.New("unknown validator")
+
+// TagError reports a validate tag that could not be turned into a validator.
+type TagError struct {
+	Field string
+	Tag   string
+	Err   error
+}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("%s: invalid validate tag '%s': %v", e.Field, e.Tag, e.Err)
+}
+
+func (e *TagError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]Factory)
+)
+
+func init() {
+	Register("required", func(param, field string) (Validator, error) {
+		return &RequiredValidator{FieldName: field}, nil
+	})
+	Register("range", func(param, field string) (Validator, error) {
+		minStr, maxStr, ok := strings.Cut(param, "-")
+		if !ok {
+			return nil, errors.New("expected min-max")
+		}
+		minValue, err := strconv.ParseFloat(strings.TrimSpace(minStr), 64)
+		if err != nil {
+			return nil, err
+		}
+		maxValue, err := strconv.ParseFloat(strings.TrimSpace(maxStr), 64)
+		if err != nil {
+			return nil, err
+		}
+		return &RangeValidator{FieldName: field, Min: minValue, Max: maxValue}, nil
+	})
+	Register("len", func(param, field string) (Validator, error) {
+		length, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, err
+		}
+		return &LenValidator{FieldName: field, Length: length}, nil
+	})
+	Register("pattern", func(param, field string) (Validator, error) {
+		re, err := regexp.Compile(param)
+		if err != nil {
+			return nil, err
+		}
+		return &PatternValidator{FieldName: field, Pattern: param, Regexp: re}, nil
+	})
+	Register("in", func(param, field string) (Validator, error) {
+		if param == "" {
+			return nil, errors.New("expected values separated by '|'")
+		}
+		return &InValidator{FieldName: field, Allowed: strings.Split(param, "|")}, nil
+	})
+	Register("eq", func(param, field string) (Validator, error) {
+		if param == "" {
+			return nil, errors.New("expected a value")
+		}
+		if valInt, err := strconv.Atoi(param); err == nil {
+			return &EqValidator{FieldName: field, Value: valInt}, nil
+		}
+		if valFloat, err := strconv.ParseFloat(param, 64); err == nil {
+			return &EqValidator{FieldName: field, Value: valFloat}, nil
+		}
+		if param == "true" || param == "false" {
+			valBool, _ := strconv.ParseBool(param)
+			return &EqValidator{FieldName: field, Value: valBool}, nil
+		}
+		return &EqValidator{FieldName: field, Value: param}, nil
+	})
+	Register("gt", func(param, field string) (Validator, error) {
+		value, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &GtValidator{FieldName: field, Value: value}, nil
+	})
+	Register("lt", func(param, field string) (Validator, error) {
+		value, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &LtValidator{FieldName: field, Value: value}, nil
+	})
+	Register("contains", func(param, field string) (Validator, error) {
+		if param == "" {
+			return nil, errors.New("expected a substring")
+		}
+		return &ContainsValidator{FieldName: field, Substring: param}, nil
+	})
+	Register("email", func(param, field string) (Validator, error) {
+		return &EmailValidator{FieldName: field}, nil
+	})
+	Register("url", func(param, field string) (Validator, error) {
+		return &URLValidator{FieldName: field}, nil
+	})
+	Register("alpha", func(param, field string) (Validator, error) {
+		return &AlphaValidator{FieldName: field}, nil
+	})
+	Register("alphanum", func(param, field string) (Validator, error) {
+		return &AlphanumValidator{FieldName: field}, nil
+	})
+
+	// Cross-field rules name another field, followed by a value to compare with if needed
+	Register("required_if", func(param, field string) (Validator, error) {
+		other, value, err := fieldAndValue(param)
+		if err != nil {
+			return nil, err
+		}
+		return &RequiredIfValidator{FieldName: field, Other: other, Value: value}, nil
+	})
+	Register("required_unless", func(param, field string) (Validator, error) {
+		other, value, err := fieldAndValue(param)
+		if err != nil {
+			return nil, err
+		}
+		return &RequiredUnlessValidator{FieldName: field, Other: other, Value: value}, nil
+	})
+	Register("required_with", func(param, field string) (Validator, error) {
+		if param == "" {
+			return nil, errors.New("expected a field name")
+		}
+		return &RequiredWithValidator{FieldName: field, Other: param}, nil
+	})
+	Register("excluded_with", func(param, field string) (Validator, error) {
+		if param == "" {
+			return nil, errors.New("expected a field name")
+		}
+		return &ExcludedWithValidator{FieldName: field, Other: param}, nil
+	})
+	Register("gtfield", func(param, field string) (Validator, error) {
+		if param == "" {
+			return nil, errors.New("expected a field name")
+		}
+		return &GtFieldValidator{FieldName: field, Other: param}, nil
+	})
+	Register("eqfield", func(param, field string) (Validator, error) {
+		if param == "" {
+			return nil, errors.New("expected a field name")
+		}
+		return &EqFieldValidator{FieldName: field, Other: param}, nil
+	})
+}
+
+// Register makes a validator available under the given tag name in validate struct tags,
+// e.g. Register("semver", factory) for validate:"semver" or validate:"semver=2".
+// Registering a name that already has a factory, including a built-in one, replaces it.
+// Validators implementing MessageSetter also accept error_<name> messages.
+// Register panics if the name is empty, contains '=' or ',', starts with "error_",
+// or if factory is nil.
+func Register(name string, factory Factory) {
+	if name == "" || strings.ContainsAny(name, "=, ") || strings.HasPrefix(name, "error_") {
+		panic("validator: invalid tag name '" + name + "'")
+	}
+	if factory == nil {
+		panic("validator: nil factory for tag '" + name + "'")
+	}
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	factories[name] = factory
+}
+
+// lookupFactory returns the factory registered under the given tag name.
+func lookupFactory(name string) (Factory, bool) {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	factory, ok := factories[name]
+	return factory, ok
+}
+
+// fieldAndValue splits a "Field value" parameter.
+func fieldAndValue(param string) (string, string, error) {
+	other, value, ok := strings.Cut(param, " ")
+	if !ok || other == "" {
+		return "", "", errors.New("expected a field name and a value")
+	}
+	return other, strings.TrimSpace(value), nil
+}
@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var semverRegexp = regexp.MustCompile(`^v?\d+\.\d+\.\d+$`)
+
+type semverValidator struct {
+	FieldName    string
+	Major        string
+	ErrorMessage string
+}
+
+func (v *semverValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
+func (v *semverValidator) Validate(value any) error {
+	s, _ := value.(string)
+	if !semverRegexp.MatchString(s) || (v.Major != "" && !strings.HasPrefix(strings.TrimPrefix(s, "v"), v.Major+".")) {
+		return createValidatorError(v.FieldName, getErrorMessage("must be a semantic version", v.ErrorMessage))
+	}
+	return nil
+}
+
+func init() {
+	Register("semver", func(param string, field string) (Validator, error) {
+		if param != "" && strings.Trim(param, "0123456789") != "" {
+			return nil, fmt.Errorf("major version '%s' is not a number", param)
+		}
+		return &semverValidator{FieldName: field, Major: param}, nil
+	})
+}
+
+func TestRegister(t *testing.T) {
+	validators, err := ParseTags("required,semver=2,error_semver=must be a 2.x version", "version")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(validators) != 2 {
+		t.Fatalf("Expected 2 validators, got %d", len(validators))
+	}
+	if err := validators[1].Validate("v2.1.0"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	err = validators[1].Validate("1.0.0")
+	if err == nil || err.Error() != "version: must be a 2.x version" {
+		t.Fatalf("Expected custom semver error, got %v", err)
+	}
+}
+
+func TestRegisterInvalidName(t *testing.T) {
+	for _, name := range []string{"", "a=b", "a,b", "error_semver"} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic for tag name '%s'", name)
+				}
+			}()
+			Register(name, func(param, field string) (Validator, error) { return nil, nil })
+		}()
+	}
+}
+
+func TestParseTagsErrors(t *testing.T) {
+	tests := []struct {
+		tags string
+		want string
+	}{
+		{"semvr", "version: invalid validate tag 'semvr': unknown validator"},
+		{"semver=x", "version: invalid validate tag 'semver=x': major version 'x' is not a number"},
+		{"range=1", "version: invalid validate tag 'range=1': expected min-max"},
+		{"required,error_email=bad email", "version: invalid validate tag 'error_email=bad email': no 'email' validator accepting a message"},
+	}
+	for _, tt := range tests {
+		validators, err := ParseTags(tt.tags, "version")
+		var tagErr *TagError
+		if !errors.As(err, &tagErr) {
+			t.Fatalf("Expected TagError for '%s', got %v", tt.tags, err)
+		}
+		if err.Error() != tt.want {
+			t.Errorf("Expected error '%s', got '%s'", tt.want, err.Error())
+		}
+		if strings.HasPrefix(tt.tags, "required") && len(validators) != 1 {
+			t.Errorf("Expected the valid tags of '%s' to be returned", tt.tags)
+		}
+	}
+
+	_, err := ParseTags("semvr", "version")
+	if !errors.Is(err, ErrUnknownTag) {
+		t.Fatalf("Expected ErrUnknownTag, got %v", err)
+	}
+	if validators := ValidateTags("required,semvr", "version"); len(validators) != 1 {
+		t.Fatalf("Expected ValidateTags to skip unknown tags, got %d validators", len(validators))
+	}
+}
@@ -5,6 +5,29 @@ type RequiredValidator struct {
 	ErrorMessage string
 }
 
+// String returns the rule in validate tag form.
+func (v *RequiredValidator) String() string {
+	return "required"
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *RequiredValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
+// ValidateSet accepts any explicitly provided value, including zero values
+// such as 0 or false, except for empty strings.
+// Values that were not provided fall back to the zero value checks of Validate.
+func (v *RequiredValidator) ValidateSet(value any, set bool) error {
+	if !set {
+		return v.Validate(value)
+	}
+	if s, ok := value.(string); ok && s == "" {
+		return createValidatorError(v.FieldName, getErrorMessage(defaultRequiredMsg, v.ErrorMessage))
+	}
+	return nil
+}
+
 func (v *RequiredValidator) Validate(value any) error {
 	errMsg := getErrorMessage(defaultRequiredMsg, v.ErrorMessage)
 	err := createValidatorError(v.FieldName, errMsg)
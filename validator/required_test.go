@@ -45,3 +45,19 @@ func TestRequiredBoolean(t *testing.T) {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 }
+
+func TestRequiredValidateSet(t *testing.T) {
+	v := Required().(SetAwareValidator)
+	if err := v.ValidateSet(0, true); err != nil {
+		t.Fatalf("Expected no error for explicitly set zero, got %v", err)
+	}
+	if err := v.ValidateSet([]int{0}, true); err != nil {
+		t.Fatalf("Expected no error for explicitly set zero slice, got %v", err)
+	}
+	if err := v.ValidateSet(0, false); err == nil {
+		t.Fatal("Expected error for zero value that was not set")
+	}
+	if err := v.ValidateSet("", true); err == nil {
+		t.Fatal("Expected error for explicitly set empty string")
+	}
+}
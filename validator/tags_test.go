@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -180,3 +182,61 @@ func TestParseValidateTagsWhitespace(t *testing.T) {
 		t.Fatalf("Expected 2 validators, got %d", len(validators))
 	}
 }
+
+func TestValidatorString(t *testing.T) {
+	tags := "required,range=1-65535,len=5,eq=10,gt=0,lt=1.5,in=dev|prod,contains=x,email,url,alpha,alphanum,pattern=^[a-z]+$"
+	validators := ValidateTags(tags, "field")
+	var rules []string
+	for _, v := range validators {
+		rules = append(rules, v.(fmt.Stringer).String())
+	}
+	if got := strings.Join(rules, ","); got != tags {
+		t.Fatalf("Expected rules '%s', got '%s'", tags, got)
+	}
+}
+
+func TestSplitTags(t *testing.T) {
+	tests := []struct {
+		tags string
+		want []string
+	}{
+		{"required,len=5", []string{"required", "len=5"}},
+		{"pattern=^a{2,}$,required", []string{"pattern=^a{2,}$", "required"}},
+		{"required,error_required=Name, please", []string{"required", "error_required=Name, please"}},
+		{"in=a|b,error_in=a, or b", []string{"in=a|b", "error_in=a, or b"}},
+		{`contains=a\,b,required`, []string{"contains=a,b", "required"}},
+		{"contains='a,email',required", []string{"contains='a,email'", "required"}},
+		{`error_eq="a, or email",eq=a`, []string{`error_eq="a, or email"`, "eq=a"}},
+		{"required,,len=5, ", []string{"required", "len=5"}},
+		{"required,semvr", []string{"required", "semvr"}},
+	}
+	for _, tt := range tests {
+		got := splitTags(tt.tags)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) || len(got) != len(tt.want) {
+			t.Errorf("splitTags(%q) = %q, want %q", tt.tags, got, tt.want)
+		}
+	}
+}
+
+func TestParseTagsCommaValues(t *testing.T) {
+	validators, err := ParseTags(`required,in=a\,b|c,contains='a,b',error_contains=Must contain "a,b", please`, "field")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(validators) != 3 {
+		t.Fatalf("Expected 3 validators, got %d", len(validators))
+	}
+	if err := validators[1].Validate("a,b"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := validators[1].Validate("a"); err == nil {
+		t.Fatal("Expected error for value not in list")
+	}
+	if err := validators[2].Validate("x"); err == nil || !strings.Contains(err.Error(), `Must contain "a,b", please`) {
+		t.Fatalf("Expected custom message, got %v", err)
+	}
+
+	if _, err := ParseTags("required,semvr", "field"); err == nil {
+		t.Fatal("Expected error for unknown tag")
+	}
+}
@@ -9,6 +9,16 @@ type URLValidator struct {
 	ErrorMessage string
 }
 
+// String returns the rule in validate tag form.
+func (v *URLValidator) String() string {
+	return "url"
+}
+
+// SetErrorMessage replaces the default error message.
+func (v *URLValidator) SetErrorMessage(msg string) {
+	v.ErrorMessage = msg
+}
+
 func (v *URLValidator) Validate(value any) error {
 	errMsg := getErrorMessage(defaultURLMsg, v.ErrorMessage)
 
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 )
@@ -11,6 +12,41 @@ type Validator interface {
 	Validate(value any) error
 }
 
+// SetAwareValidator is implemented by validators whose result depends on whether
+// a value was explicitly provided, rather than only on the value itself.
+// Callers that know whether a value was set should prefer ValidateSet over Validate.
+type SetAwareValidator interface {
+	Validator
+	ValidateSet(value any, set bool) error
+}
+
+// MessageSetter is implemented by validators whose default error message can be
+// replaced, which lets error_<tag> entries in validate tags apply to them.
+type MessageSetter interface {
+	SetErrorMessage(msg string)
+}
+
+// Field is a sibling field of the value being validated, as seen by a CrossFieldValidator.
+// Name is how the field is referred to in error messages.
+type Field struct {
+	Name  string
+	Value any
+	Set   bool
+}
+
+// FieldLookup resolves a sibling field by its struct field name.
+type FieldLookup func(name string) (Field, bool)
+
+// CrossFieldValidator is implemented by validators that compare a value with
+// another field of the same struct, such as required_if or gtfield.
+// RelatedField returns the struct field name the rule refers to.
+// Validate alone cannot see the other field and reports no error.
+type CrossFieldValidator interface {
+	SetAwareValidator
+	RelatedField() string
+	ValidateFields(value any, set bool, fields FieldLookup) error
+}
+
 // ValidatorFunc is a function type that implements the Validator interface
 type ValidatorFunc func(value any) error
 
@@ -49,6 +85,16 @@ func (e *ValidatorError) Error() string {
 
 // ValidateTags parses validate tags and creates corresponding validators
 // This function is exported for use by the main cliz package
+// Tags that cannot be parsed are skipped, see ParseTags to have them reported.
 func ValidateTags(validateTags, fieldName string) []Validator {
-	return parseValidateTags(validateTags, fieldName)
+	validators, _ := parseValidateTags(validateTags, fieldName)
+	return validators
+}
+
+// ParseTags parses validate tags like ValidateTags, and reports unknown tags,
+// invalid parameters and error_<tag> messages without a matching tag as a *TagError.
+// The validators of the valid tags are returned along with the error.
+func ParseTags(validateTags, fieldName string) ([]Validator, error) {
+	validators, errs := parseValidateTags(validateTags, fieldName)
+	return validators, errors.Join(errs...)
 }
@@ -0,0 +1,254 @@
+package cliz
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Parser converts a single command line string into a value of type T.
+// Parsers are registered with RegisterParser and looked up by type when
+// flags are added with Var or AddFlags.
+type Parser[T any] func(value string) (T, error)
+
+// valueParser is the type-erased form of a registered Parser.
+// It knows how to wrap both *T and *[]T variables into flag values.
+type valueParser struct {
+	typeName      string
+	parse         func(string) (any, error)
+	newValue      func(variable any) flag.Value
+	newSliceValue func(variable any) flag.Value
+}
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = make(map[reflect.Type]*valueParser)
+)
+
+// Integers are parsed in base 10, so zero-padded values such as 08 keep their decimal meaning.
+func init() {
+	RegisterParser(strconv.ParseBool)
+	RegisterParser(func(s string) (string, error) { return s, nil })
+	RegisterParser(func(s string) (int, error) {
+		v, err := strconv.ParseInt(s, 10, strconv.IntSize)
+		return int(v), err
+	})
+	RegisterParser(func(s string) (int8, error) {
+		v, err := strconv.ParseInt(s, 10, 8)
+		return int8(v), err
+	})
+	RegisterParser(func(s string) (int16, error) {
+		v, err := strconv.ParseInt(s, 10, 16)
+		return int16(v), err
+	})
+	RegisterParser(func(s string) (int32, error) {
+		v, err := strconv.ParseInt(s, 10, 32)
+		return int32(v), err
+	})
+	RegisterParser(func(s string) (int64, error) {
+		return strconv.ParseInt(s, 10, 64)
+	})
+	RegisterParser(func(s string) (uint, error) {
+		v, err := strconv.ParseUint(s, 10, strconv.IntSize)
+		return uint(v), err
+	})
+	RegisterParser(func(s string) (uint8, error) {
+		v, err := strconv.ParseUint(s, 10, 8)
+		return uint8(v), err
+	})
+	RegisterParser(func(s string) (uint16, error) {
+		v, err := strconv.ParseUint(s, 10, 16)
+		return uint16(v), err
+	})
+	RegisterParser(func(s string) (uint32, error) {
+		v, err := strconv.ParseUint(s, 10, 32)
+		return uint32(v), err
+	})
+	RegisterParser(func(s string) (uint64, error) {
+		return strconv.ParseUint(s, 10, 64)
+	})
+	RegisterParser(func(s string) (float32, error) {
+		v, err := strconv.ParseFloat(s, 32)
+		return float32(v), err
+	})
+	RegisterParser(func(s string) (float64, error) {
+		return strconv.ParseFloat(s, 64)
+	})
+	RegisterParser(time.ParseDuration)
+}
+
+// RegisterParser registers the parser used for flags of type T and []T.
+// Registering a parser for a type that already has one replaces it.
+// Parsers should be registered before any flags of that type are added,
+// typically from an init function.
+func RegisterParser[T any](parse Parser[T]) {
+	typ := reflect.TypeFor[T]()
+	name := typ.String()
+	if typ.Name() != "" {
+		name = strings.ToLower(typ.Name())
+	}
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+	parsers[typ] = &valueParser{
+		typeName: name,
+		parse: func(s string) (any, error) {
+			return parse(s)
+		},
+		newValue: func(variable any) flag.Value {
+			return NewFlag(variable.(*T), parse)
+		},
+		newSliceValue: func(variable any) flag.Value {
+			return NewSliceFlag(variable.(*[]T), parse)
+		},
+	}
+}
+
+// lookupParser returns the parser registered for the given type.
+func lookupParser(typ reflect.Type) (*valueParser, bool) {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+	p, ok := parsers[typ]
+	return p, ok
+}
+
+// newFlagValue wraps the variable pointed to by ptr into a flag.Value.
+// Slices of a registered element type, including named slice types such as
+// type Tags []string, are wrapped into a SliceFlag.
+// The second return value is false if no parser is registered for the type.
+func newFlagValue(ptr reflect.Value) (flag.Value, bool) {
+	typ := ptr.Type().Elem()
+	if p, ok := lookupParser(typ); ok {
+		return p.newValue(ptr.Interface()), true
+	}
+	if typ.Kind() == reflect.Slice {
+		if p, ok := lookupParser(typ.Elem()); ok {
+			// A *Tags shares its underlying type with *[]string and converts to it
+			slicePtr := ptr.Convert(reflect.PointerTo(reflect.SliceOf(typ.Elem())))
+			return p.newSliceValue(slicePtr.Interface()), true
+		}
+	}
+	return nil, false
+}
+
+// Var adds a flag of any registered type to the command.
+// T may be a registered type or a slice of one, in which case the flag can be
+// repeated and every value is appended to the slice.
+// Var panics if no parser is registered for T.
+func Var[T any](c *Command, name, description string, variable *T, validators ...Validator) *Command {
+	ptr := reflect.ValueOf(variable)
+	value, ok := newFlagValue(ptr)
+	if !ok {
+		panic("Var '" + name + "' has no parser registered for type " + ptr.Type().Elem().String())
+	}
+	return c.addFlag(name, description, value, ptr.Elem(), validators)
+}
+
+// Flag is a flag.Value that stores a single value of type T.
+type Flag[T any] struct {
+	variable *T
+	parse    Parser[T]
+}
+
+// NewFlag creates a Flag that parses values with parse and stores them in variable.
+func NewFlag[T any](variable *T, parse Parser[T]) *Flag[T] {
+	return &Flag[T]{variable: variable, parse: parse}
+}
+
+func (f *Flag[T]) String() string {
+	if f == nil || f.variable == nil {
+		return ""
+	}
+	return fmt.Sprint(*f.variable)
+}
+
+func (f *Flag[T]) Set(value string) error {
+	v, err := f.parse(value)
+	if err != nil {
+		return err
+	}
+	*f.variable = v
+	return nil
+}
+
+// Get returns the current value of the flag.
+func (f *Flag[T]) Get() any {
+	return *f.variable
+}
+
+// Type returns the name of the flag's value type.
+func (f *Flag[T]) Type() string {
+	return typeName(reflect.TypeFor[T]())
+}
+
+// IsBoolFlag reports whether the flag can be given without a value.
+func (f *Flag[T]) IsBoolFlag() bool {
+	return reflect.TypeFor[T]().Kind() == reflect.Bool
+}
+
+// SliceFlag is a flag.Value that collects repeated values of type T.
+// Every occurrence of the flag appends to the slice, except that the first
+// occurrence replaces a default set from a 'default' tag.
+type SliceFlag[T any] struct {
+	variable  *[]T
+	parse     Parser[T]
+	defaulted bool // Whether the slice still holds its default
+}
+
+// NewSliceFlag creates a SliceFlag that parses values with parse and appends them to variable.
+func NewSliceFlag[T any](variable *[]T, parse Parser[T]) *SliceFlag[T] {
+	return &SliceFlag[T]{variable: variable, parse: parse}
+}
+
+func (f *SliceFlag[T]) String() string {
+	if f == nil || f.variable == nil {
+		return ""
+	}
+	return fmt.Sprint(*f.variable)
+}
+
+func (f *SliceFlag[T]) Set(value string) error {
+	v, err := f.parse(value)
+	if err != nil {
+		return err
+	}
+	if f.defaulted {
+		*f.variable = nil
+		f.defaulted = false
+	}
+	*f.variable = append(*f.variable, v)
+	return nil
+}
+
+// setDefault replaces the slice with the given default values.
+// The next call to Set discards them. Values that cannot be parsed are skipped.
+func (f *SliceFlag[T]) setDefault(values []string) {
+	*f.variable = nil
+	for _, value := range values {
+		if v, err := f.parse(value); err == nil {
+			*f.variable = append(*f.variable, v)
+		}
+	}
+	f.defaulted = true
+}
+
+// Get returns the current value of the flag.
+func (f *SliceFlag[T]) Get() any {
+	return *f.variable
+}
+
+// Type returns the name of the flag's value type.
+func (f *SliceFlag[T]) Type() string {
+	return "[]" + typeName(reflect.TypeFor[T]())
+}
+
+// typeName returns the display name of a registered type.
+func typeName(typ reflect.Type) string {
+	if p, ok := lookupParser(typ); ok {
+		return p.typeName
+	}
+	return typ.String()
+}
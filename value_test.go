@@ -0,0 +1,165 @@
+package cliz
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testLevel int
+
+type testTags []string
+
+func TestVarScalar(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var timeout time.Duration
+	Var(cli.RootCommand(), "timeout", "set timeout", &timeout)
+	err := cli.Run("--timeout=1m30s")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if timeout != 90*time.Second {
+		t.Fatalf("Expected timeout 1m30s, got %v", timeout)
+	}
+}
+
+func TestVarSlice(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var timeouts []time.Duration
+	Var(cli.RootCommand(), "timeout", "set timeout", &timeouts)
+	err := cli.Run("--timeout=1s", "--timeout=2s")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(timeouts) != 2 || timeouts[0] != time.Second || timeouts[1] != 2*time.Second {
+		t.Fatalf("Expected timeouts [1s 2s], got %v", timeouts)
+	}
+}
+
+func TestVarNamedSlice(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var tags testTags
+	Var(cli.RootCommand(), "tag", "add tag", &tags)
+	err := cli.Run("--tag=a", "--tag=b")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tags) != 2 || tags[0] != "a" || tags[1] != "b" {
+		t.Fatalf("Expected tags [a b], got %v", tags)
+	}
+
+	type Flags struct {
+		Tags testTags `name:"tags" description:"tags" default:"x,y"`
+	}
+	flags := Flags{}
+	cli = NewCli("test-app", "test description", "1.0.0")
+	cli.AddFlags(&flags)
+	err = cli.Run("--tags=z")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(flags.Tags) != 1 || flags.Tags[0] != "z" {
+		t.Fatalf("Expected tags [z], got %v", flags.Tags)
+	}
+}
+
+func TestVarWithValidators(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var count int
+	Var(cli.RootCommand(), "count", "set count", &count, Range(1, 10))
+	err := cli.Run("--count=20")
+	if err == nil {
+		t.Fatalf("Expected validation error, got nil")
+	}
+}
+
+func TestVarUnregisteredTypePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected panic for unregistered type")
+		}
+	}()
+	cmd := NewCommand("test", "test command")
+	var value struct{}
+	Var(cmd, "value", "set value", &value)
+}
+
+func TestRegisterParser(t *testing.T) {
+	RegisterParser(func(s string) (testLevel, error) {
+		switch strings.ToLower(s) {
+		case "low":
+			return 1, nil
+		case "high":
+			return 2, nil
+		}
+		return 0, errors.New("unknown level")
+	})
+
+	cli := NewCli("test-app", "test description", "1.0.0")
+	type config struct {
+		Level  testLevel   `name:"level" description:"set level" default:"low"`
+		Levels []testLevel `name:"levels" description:"set levels"`
+	}
+	var cfg config
+	cli.AddFlags(&cfg)
+	if cfg.Level != 1 {
+		t.Fatalf("Expected default level 1, got %d", cfg.Level)
+	}
+	err := cli.Run("--level=high", "--levels=low", "--levels=high")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Level != 2 {
+		t.Fatalf("Expected level 2, got %d", cfg.Level)
+	}
+	if len(cfg.Levels) != 2 || cfg.Levels[0] != 1 || cfg.Levels[1] != 2 {
+		t.Fatalf("Expected levels [1 2], got %v", cfg.Levels)
+	}
+
+	err = cli.Run("--level=medium")
+	if err == nil {
+		t.Fatalf("Expected error for unknown level, got nil")
+	}
+}
+
+func TestFlagType(t *testing.T) {
+	var count int
+	var names []string
+	var timeout time.Duration
+	if got := NewFlag(&count, nil).Type(); got != "int" {
+		t.Fatalf("Expected type 'int', got '%s'", got)
+	}
+	if got := NewSliceFlag(&names, nil).Type(); got != "[]string" {
+		t.Fatalf("Expected type '[]string', got '%s'", got)
+	}
+	if got := NewFlag(&timeout, nil).Type(); got != "duration" {
+		t.Fatalf("Expected type 'duration', got '%s'", got)
+	}
+}
+
+func TestSliceDefaultWithSeparator(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	type config struct {
+		Hosts []string `name:"hosts" description:"set hosts" default:"a;b" sep:";"`
+		Ports []int    `name:"ports" description:"set ports" default:"80, 443"`
+	}
+	var cfg config
+	cli.AddFlags(&cfg)
+	if len(cfg.Hosts) != 2 || cfg.Hosts[0] != "a" || cfg.Hosts[1] != "b" {
+		t.Fatalf("Expected hosts [a b], got %v", cfg.Hosts)
+	}
+	if len(cfg.Ports) != 2 || cfg.Ports[0] != 80 || cfg.Ports[1] != 443 {
+		t.Fatalf("Expected ports [80 443], got %v", cfg.Ports)
+	}
+}
+
+func TestInt8OutOfRange(t *testing.T) {
+	cli := NewCli("test-app", "test description", "1.0.0")
+	var value int8
+	cli.Int8("value", "set value", &value)
+	err := cli.Run("--value=300")
+	if err == nil {
+		t.Fatalf("Expected out of range error, got nil")
+	}
+}